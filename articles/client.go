@@ -30,8 +30,11 @@ func NewAPIClient(articlesAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of ArticlesApi Client,
 // reusing the URL and Clienter from the provided healthcheck client.
 func NewWithHealthClient(hcCli *health.Client) *Client {
+	hcClient := health.NewClientWithClienter(serviceName, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		health.NewClientWithClienter(serviceName, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
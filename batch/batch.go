@@ -2,6 +2,7 @@ package batch
 
 import (
 	"errors"
+	"fmt"
 	"sync"
 	"time"
 )
@@ -12,8 +13,19 @@ type GenericBatchGetter func(offset int) (batch interface{}, totalCount int, eTa
 // GenericBatchProcessor defines the method signature for a batch processor to process a batch of some generic resource
 type GenericBatchProcessor func(batch interface{}, batchETag string) (abort bool, err error)
 
+// batchDoneFunc is called after a batch at the provided offset has been successfully processed,
+// along with the total count and eTag returned by the getter for that batch
+type batchDoneFunc func(offset, totalCount int, batchETag string) error
+
 // ProcessInConcurrentBatches is a generic method to concurrently obtain some resource in batches and then process each batch
 func ProcessInConcurrentBatches(getBatch GenericBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int) (err error) {
+	return processInConcurrentBatches(getBatch, processBatch, batchSize, maxWorkers, 0, nil, nil)
+}
+
+// processInConcurrentBatches concurrently obtains and processes the batches of some resource starting at the provided offset,
+// skipping the batches after it for which isCompleted, if not nil, returns true,
+// and calling batchDone, if not nil, after each batch is successfully processed
+func processInConcurrentBatches(getBatch GenericBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers, startOffset int, isCompleted func(offset int) bool, batchDone batchDoneFunc) (err error) {
 
 	// validate paramters
 	if getBatch == nil {
@@ -78,7 +90,7 @@ func ProcessInConcurrentBatches(getBatch GenericBatchGetter, processBatch Generi
 		}
 
 		// get batch
-		batch, _, batchETag, err := getBatch(offset)
+		batch, totalCount, batchETag, err := getBatch(offset)
 		if err != nil {
 			chErr <- err
 			abort()
@@ -94,14 +106,24 @@ func ProcessInConcurrentBatches(getBatch GenericBatchGetter, processBatch Generi
 		if err != nil {
 			chErr <- err
 			abort()
+			return
 		}
 		if forceAbort {
 			abort()
+			return
+		}
+
+		// notify that the batch has been processed
+		if batchDone != nil {
+			if err := batchDone(offset, totalCount, batchETag); err != nil {
+				chErr <- err
+				abort()
+			}
 		}
 	}
 
 	// get first batch sequentially, so that we know the total count before triggering any further go-routine
-	batch, totalCount, batchETag, err := getBatch(0)
+	batch, totalCount, batchETag, err := getBatch(startOffset)
 	if err != nil {
 		return err
 	}
@@ -111,17 +133,19 @@ func ProcessInConcurrentBatches(getBatch GenericBatchGetter, processBatch Generi
 	if forceAbort || err != nil {
 		return err
 	}
-
-	// determine the total number of remaining calls, considering that we have already performed the first one
-	numCalls := totalCount / batchSize
-	if (totalCount % batchSize) == 0 {
-		numCalls--
+	if batchDone != nil {
+		if err := batchDone(startOffset, totalCount, batchETag); err != nil {
+			return err
+		}
 	}
 
-	// process remaining batches concurrently
-	for i := 0; i < numCalls; i++ {
+	// process remaining batches concurrently, considering that we have already performed the first one
+	for offset := startOffset + batchSize; offset < totalCount; offset += batchSize {
+		if isCompleted != nil && isCompleted(offset) {
+			continue
+		}
 		acquire()
-		go doProcessBatch((i + 1) * batchSize)
+		go doProcessBatch(offset)
 	}
 
 	// func that will close wait channel when all go-routines complete their execution
@@ -179,6 +203,123 @@ func ProcessInBatches(items []string, processBatch func([]string) error, batchSi
 	return processedBatches, nil
 }
 
+// ChunkResult is the result of processing a chunk of the items provided to ProcessInBatchesConcurrently
+type ChunkResult struct {
+	// Index is the position of the chunk in the items, i.e. the chunk contains items[Index*batchSize:]
+	Index int
+	Items []string
+	Err   error
+}
+
+// Report lists the chunks that were processed successfully, the ones that failed and the ones that were skipped,
+// each one sorted by chunk index, so that only the failed and skipped chunks need to be retried
+type Report struct {
+	Succeeded []ChunkResult
+	Failed    []ChunkResult
+	Skipped   []ChunkResult
+}
+
+// PendingItems returns the items of the failed and skipped chunks, which are the ones that would need to be retried
+func (r *Report) PendingItems() []string {
+	pending := []string{}
+	for _, chunks := range [][]ChunkResult{r.Failed, r.Skipped} {
+		for _, chunk := range chunks {
+			pending = append(pending, chunk.Items...)
+		}
+	}
+	return pending
+}
+
+// Err returns the errors of the failed chunks joined together, or nil if no chunk failed
+func (r *Report) Err() error {
+	errs := make([]error, len(r.Failed))
+	for i, chunk := range r.Failed {
+		errs[i] = fmt.Errorf("chunk %d failed: %w", chunk.Index, chunk.Err)
+	}
+	return errors.Join(errs...)
+}
+
+// ProcessInBatchesConcurrently is a concurrent variant of ProcessInBatches, which splits the provided items in batches
+// and calls processBatch for each batch, with up to maxWorkers concurrent calls.
+// If continueOnError is false, no more batches are processed after the first failure and the remaining ones are reported as skipped.
+// Otherwise all the batches are processed regardless of failures.
+// A report with the result of each batch is returned, along with the errors of the failed batches, if any.
+// processBatch must be safe to call concurrently, so this method is not suitable for operations that depend on the order of the batches.
+func ProcessInBatchesConcurrently(items []string, processBatch func([]string) error, batchSize, maxWorkers int, continueOnError bool) (*Report, error) {
+	// validate paramters
+	if items == nil {
+		return nil, errors.New("items cannot be nil")
+	}
+	if processBatch == nil {
+		return nil, errors.New("processBatch cannot be nil")
+	}
+	if batchSize <= 0 {
+		return nil, errors.New("batchSize must be a positive value")
+	}
+	if maxWorkers <= 0 {
+		return nil, errors.New("maxWorkers must be a positive value")
+	}
+
+	// Get batch splits for provided items
+	numChunks := (len(items) + batchSize - 1) / batchSize
+	results := make([]ChunkResult, numChunks)
+	skipped := make([]bool, numChunks)
+
+	wg := sync.WaitGroup{}
+	chSemaphore := make(chan struct{}, maxWorkers)
+	chAbort := make(chan struct{})
+	abortOnce := sync.Once{}
+
+	for i := 0; i < numChunks; i++ {
+		results[i] = ChunkResult{
+			Index: i,
+			Items: items[i*batchSize : Min((i+1)*batchSize, len(items))],
+		}
+
+		// acquire semaphore, unless the process is being aborted, in which case the chunk is skipped
+		select {
+		case <-chAbort:
+			skipped[i] = true
+			continue
+		case chSemaphore <- struct{}{}:
+		}
+		select {
+		case <-chAbort:
+			<-chSemaphore
+			skipped[i] = true
+			continue
+		default:
+		}
+
+		wg.Add(1)
+		go func(result *ChunkResult) {
+			defer func() {
+				<-chSemaphore
+				wg.Done()
+			}()
+
+			if result.Err = processBatch(result.Items); result.Err != nil && !continueOnError {
+				abortOnce.Do(func() { close(chAbort) })
+			}
+		}(&results[i])
+	}
+
+	wg.Wait()
+
+	report := &Report{}
+	for i, result := range results {
+		switch {
+		case skipped[i]:
+			report.Skipped = append(report.Skipped, result)
+		case result.Err != nil:
+			report.Failed = append(report.Failed, result)
+		default:
+			report.Succeeded = append(report.Succeeded, result)
+		}
+	}
+	return report, report.Err()
+}
+
 // Min returns the lowest value
 func Min(x, y int) int {
 	if x < y {
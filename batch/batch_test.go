@@ -2,6 +2,7 @@ package batch
 
 import (
 	"errors"
+	"sync"
 	"testing"
 
 	. "github.com/smartystreets/goconvey/convey"
@@ -281,3 +282,86 @@ func TestProcessInBatches(t *testing.T) {
 		})
 	})
 }
+
+func TestProcessInBatchesConcurrently(t *testing.T) {
+
+	Convey("Given an array of 10 items", t, func() {
+		items := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+		// chunk processor mock generator and calls tracker, which fails for the chunks that start with the provided items
+		lockCalls := sync.Mutex{}
+		processedChunks := [][]string{}
+		processor := func(failFor ...string) func([]string) error {
+			return func(chunk []string) error {
+				lockCalls.Lock()
+				defer lockCalls.Unlock()
+				processedChunks = append(processedChunks, chunk)
+				for _, item := range failFor {
+					if chunk[0] == item {
+						return errProcessor
+					}
+				}
+				return nil
+			}
+		}
+
+		Convey("Then processing in chunks of size 3 with 2 workers results in all the chunks being processed successfully", func() {
+			report, err := ProcessInBatchesConcurrently(items, processor(), 3, 2, false)
+			So(err, ShouldBeNil)
+			So(processedChunks, ShouldHaveLength, 4)
+			So(report.Succeeded, ShouldResemble, []ChunkResult{
+				{Index: 0, Items: []string{"0", "1", "2"}},
+				{Index: 1, Items: []string{"3", "4", "5"}},
+				{Index: 2, Items: []string{"6", "7", "8"}},
+				{Index: 3, Items: []string{"9"}},
+			})
+			So(report.Failed, ShouldBeEmpty)
+			So(report.Skipped, ShouldBeEmpty)
+			So(report.PendingItems(), ShouldBeEmpty)
+		})
+
+		Convey("Then processing in chunks of size 3 with a processor that fails for 2 chunks and continueOnError results in all the chunks being processed and the failures being reported", func() {
+			report, err := ProcessInBatchesConcurrently(items, processor("3", "9"), 3, 2, true)
+			So(err, ShouldNotBeNil)
+			So(errors.Is(err, errProcessor), ShouldBeTrue)
+			So(err.Error(), ShouldEqual, "chunk 1 failed: BatchProcessor error\nchunk 3 failed: BatchProcessor error")
+			So(processedChunks, ShouldHaveLength, 4)
+			So(report.Succeeded, ShouldResemble, []ChunkResult{
+				{Index: 0, Items: []string{"0", "1", "2"}},
+				{Index: 2, Items: []string{"6", "7", "8"}},
+			})
+			So(report.Failed, ShouldResemble, []ChunkResult{
+				{Index: 1, Items: []string{"3", "4", "5"}, Err: errProcessor},
+				{Index: 3, Items: []string{"9"}, Err: errProcessor},
+			})
+			So(report.PendingItems(), ShouldResemble, []string{"3", "4", "5", "9"})
+		})
+
+		Convey("Then processing in chunks of size 3 with 1 worker and a processor that fails for the first chunk without continueOnError results in the remaining chunks being skipped", func() {
+			report, err := ProcessInBatchesConcurrently(items, processor("0"), 3, 1, false)
+			So(errors.Is(err, errProcessor), ShouldBeTrue)
+			So(report.Succeeded, ShouldBeEmpty)
+			So(report.Failed, ShouldResemble, []ChunkResult{
+				{Index: 0, Items: []string{"0", "1", "2"}, Err: errProcessor},
+			})
+			So(processedChunks, ShouldHaveLength, 1)
+			So(report.Skipped, ShouldResemble, []ChunkResult{
+				{Index: 1, Items: []string{"3", "4", "5"}},
+				{Index: 2, Items: []string{"6", "7", "8"}},
+				{Index: 3, Items: []string{"9"}},
+			})
+			So(report.PendingItems(), ShouldResemble, items)
+		})
+
+		Convey("Then calling ProcessInBatchesConcurrently with invalid parameters results in the expected errors being returned", func() {
+			_, err := ProcessInBatchesConcurrently(nil, processor(), 3, 1, false)
+			So(err.Error(), ShouldEqual, "items cannot be nil")
+			_, err = ProcessInBatchesConcurrently(items, nil, 3, 1, false)
+			So(err.Error(), ShouldEqual, "processBatch cannot be nil")
+			_, err = ProcessInBatchesConcurrently(items, processor(), 0, 1, false)
+			So(err.Error(), ShouldEqual, "batchSize must be a positive value")
+			_, err = ProcessInBatchesConcurrently(items, processor(), 3, 0, false)
+			So(err.Error(), ShouldEqual, "maxWorkers must be a positive value")
+		})
+	})
+}
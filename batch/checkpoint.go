@@ -0,0 +1,212 @@
+package batch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ErrResourceChanged is returned when resuming a batch process from a checkpoint
+// if the eTag of the resource is different from the one saved in the checkpoint
+var ErrResourceChanged = errors.New("resource changed since the last checkpoint")
+
+// Checkpoint represents the progress of a batch process
+type Checkpoint struct {
+	// Offset is the offset of the first item that has not been processed. All the items before it have been processed.
+	Offset int `json:"offset"`
+	// Completed are the offsets of the batches after Offset that have already been processed, in ascending order
+	Completed []int `json:"completed,omitempty"`
+	// Cursor is an opaque value saved with the checkpoint, e.g. the state of the output that the batches are written to
+	Cursor string `json:"cursor,omitempty"`
+	// ETag is the eTag of the resource when its batches were processed
+	ETag string `json:"etag,omitempty"`
+}
+
+// CheckpointStore defines the methods required to persist the checkpoints of a batch process
+type CheckpointStore interface {
+	// Load returns the last saved checkpoint, or nil if there is none
+	Load() (*Checkpoint, error)
+	// Save persists the provided checkpoint, replacing any previous one
+	Save(checkpoint Checkpoint) error
+	// Clear removes any saved checkpoint
+	Clear() error
+}
+
+// ProcessInConcurrentBatchesWithCheckpoint is a variant of ProcessInConcurrentBatches that saves a checkpoint in the provided store
+// every time a batch is processed, so that a process can be resumed after a failure without processing the completed batches again.
+// Batches may complete out of order, so the checkpoint contains the offset up to which all the batches have been processed,
+// along with the offsets of the batches after it that have also been processed, which are skipped when resuming.
+// If a checkpoint is found in the store, processing starts at its offset, and an error wrapping ErrResourceChanged is returned
+// if any batch is obtained with an eTag that is different from the one in the checkpoint, or if the checkpoint offset is beyond
+// the total count of the resource, in which case the checkpoint is cleared.
+// If cursor is not nil, it is called to obtain the cursor saved with each checkpoint, after the batch has been processed.
+// The checkpoint is cleared once all batches are processed.
+func ProcessInConcurrentBatchesWithCheckpoint(getBatch GenericBatchGetter, processBatch GenericBatchProcessor, batchSize, maxWorkers int, store CheckpointStore, cursor func() string) error {
+	if store == nil {
+		return errors.New("store cannot be nil")
+	}
+	if batchSize <= 0 {
+		return errors.New("batchSize must be a positive value")
+	}
+
+	checkpoint, err := store.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load checkpoint: %w", err)
+	}
+
+	startOffset := 0
+	completed := map[int]bool{}
+	if checkpoint != nil {
+		startOffset = checkpoint.Offset
+		for _, offset := range checkpoint.Completed {
+			completed[offset] = true
+		}
+
+		// validate that the resource did not shrink below the checkpoint offset, which is the first offset requested
+		if startOffset > 0 && getBatch != nil {
+			getBatchBeyondEnd := getBatch
+			getBatch = func(offset int) (interface{}, int, string, error) {
+				b, totalCount, eTag, err := getBatchBeyondEnd(offset)
+				if err == nil && offset == startOffset && offset >= totalCount {
+					if err := store.Clear(); err != nil {
+						return nil, 0, "", fmt.Errorf("failed to clear checkpoint: %w", err)
+					}
+					return nil, 0, "", fmt.Errorf("%w: checkpoint offset %d, current total count %d", ErrResourceChanged, offset, totalCount)
+				}
+				return b, totalCount, eTag, err
+			}
+		}
+
+		// validate that the resource did not change since the checkpoint was saved
+		if checkpoint.ETag != "" && getBatch != nil {
+			getBatchFromCheckpoint := getBatch
+			getBatch = func(offset int) (interface{}, int, string, error) {
+				b, totalCount, eTag, err := getBatchFromCheckpoint(offset)
+				if err == nil && eTag != checkpoint.ETag {
+					return nil, 0, "", fmt.Errorf("%w: checkpoint eTag %q, current eTag %q", ErrResourceChanged, checkpoint.ETag, eTag)
+				}
+				return b, totalCount, eTag, err
+			}
+		}
+	}
+
+	t := &checkpointTracker{
+		store:     store,
+		cursor:    cursor,
+		batchSize: batchSize,
+		offset:    startOffset,
+		processed: map[int]bool{},
+	}
+	for offset := range completed {
+		t.processed[offset] = true
+	}
+	isCompleted := func(offset int) bool {
+		return completed[offset]
+	}
+	return processInConcurrentBatches(getBatch, processBatch, batchSize, maxWorkers, startOffset, isCompleted, t.batchDone)
+}
+
+// checkpointTracker keeps track of the processed batches and saves a checkpoint every time a batch is processed
+type checkpointTracker struct {
+	store     CheckpointStore
+	cursor    func() string
+	batchSize int
+	offset    int
+	processed map[int]bool
+}
+
+// batchDone records the batch at the provided offset as processed and saves a checkpoint. Batches may be processed in any order,
+// so the checkpoint offset only advances once all the previous batches have been processed, and the offsets of the batches processed
+// after it are saved as completed. Calls are serialised by processInConcurrentBatches.
+func (t *checkpointTracker) batchDone(offset, totalCount int, batchETag string) error {
+	t.processed[offset] = true
+	for t.processed[t.offset] {
+		delete(t.processed, t.offset)
+		t.offset += t.batchSize
+	}
+
+	if t.offset >= totalCount {
+		if err := t.store.Clear(); err != nil {
+			return fmt.Errorf("failed to clear checkpoint: %w", err)
+		}
+		return nil
+	}
+
+	checkpoint := Checkpoint{
+		Offset: t.offset,
+		ETag:   batchETag,
+	}
+	for completed := range t.processed {
+		checkpoint.Completed = append(checkpoint.Completed, completed)
+	}
+	sort.Ints(checkpoint.Completed)
+	if t.cursor != nil {
+		checkpoint.Cursor = t.cursor()
+	}
+	if err := t.store.Save(checkpoint); err != nil {
+		return fmt.Errorf("failed to save checkpoint: %w", err)
+	}
+	return nil
+}
+
+// FileCheckpointStore is a CheckpointStore that persists the checkpoint as a json file
+type FileCheckpointStore struct {
+	path string
+}
+
+// NewFileCheckpointStore creates a new FileCheckpointStore that persists the checkpoint in the file with the provided path
+func NewFileCheckpointStore(path string) *FileCheckpointStore {
+	return &FileCheckpointStore{path: path}
+}
+
+// Load returns the checkpoint saved in the file, or nil if the file does not exist
+func (s *FileCheckpointStore) Load() (*Checkpoint, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var checkpoint Checkpoint
+	if err := json.Unmarshal(b, &checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal checkpoint file: %w", err)
+	}
+	return &checkpoint, nil
+}
+
+// Save writes the checkpoint to a temporary file, which then replaces the checkpoint file,
+// so that the checkpoint file is never left partially written
+func (s *FileCheckpointStore) Save(checkpoint Checkpoint) error {
+	b, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), s.path)
+}
+
+// Clear removes the checkpoint file, if it exists
+func (s *FileCheckpointStore) Clear() error {
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
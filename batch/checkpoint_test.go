@@ -0,0 +1,214 @@
+package batch
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// memoryCheckpointStore is a CheckpointStore that keeps track of all the saved checkpoints in memory
+type memoryCheckpointStore struct {
+	saved   []Checkpoint
+	cleared bool
+}
+
+func (s *memoryCheckpointStore) Load() (*Checkpoint, error) {
+	if len(s.saved) == 0 || s.cleared {
+		return nil, nil
+	}
+	return &s.saved[len(s.saved)-1], nil
+}
+
+func (s *memoryCheckpointStore) Save(checkpoint Checkpoint) error {
+	s.saved = append(s.saved, checkpoint)
+	return nil
+}
+
+func (s *memoryCheckpointStore) Clear() error {
+	s.cleared = true
+	return nil
+}
+
+func TestProcessInConcurrentBatchesWithCheckpoint(t *testing.T) {
+
+	Convey("Given a slice of 10 items and a checkpoint file", t, func() {
+		full := []string{"0", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+		store := NewFileCheckpointStore(filepath.Join(t.TempDir(), "checkpoint.json"))
+		batchSize := 3
+		maxWorkers := 1
+
+		// batch getter mock generator and calls tracker
+		batchGetterCalls := []int{}
+		batchGetter := func(eTag string) GenericBatchGetter {
+			return func(offset int) (interface{}, int, string, error) {
+				batchGetterCalls = append(batchGetterCalls, offset)
+				if offset >= len(full) {
+					return []string{}, len(full), eTag, nil
+				}
+				end := Min(offset+batchSize, len(full))
+				return full[offset:end], len(full), eTag, nil
+			}
+		}
+
+		// batch processor mock generator and calls tracker, which fails for the batch at the provided offset
+		batchProcessorCalls := []interface{}{}
+		batchProcessor := func(failAt string) GenericBatchProcessor {
+			return func(batch interface{}, batchETag string) (abort bool, err error) {
+				batchProcessorCalls = append(batchProcessorCalls, batch)
+				if batch.([]string)[0] == failAt {
+					return false, errProcessor
+				}
+				return false, nil
+			}
+		}
+
+		cursor := func() string {
+			return fmt.Sprintf("cursor%d", len(batchProcessorCalls))
+		}
+
+		Convey("When the batches are processed with a processor that fails for the third batch", func() {
+			err := ProcessInConcurrentBatchesWithCheckpoint(batchGetter(testETag), batchProcessor("6"), batchSize, maxWorkers, store, cursor)
+			So(err, ShouldEqual, errProcessor)
+
+			Convey("Then a checkpoint is saved with the offset after the last processed batch, its eTag and the cursor", func() {
+				checkpoint, err := store.Load()
+				So(err, ShouldBeNil)
+				So(checkpoint, ShouldResemble, &Checkpoint{Offset: 6, Cursor: "cursor2", ETag: testETag})
+			})
+
+			Convey("And the process is resumed with a processor that succeeds", func() {
+				batchGetterCalls = []int{}
+				batchProcessorCalls = []interface{}{}
+				err := ProcessInConcurrentBatchesWithCheckpoint(batchGetter(testETag), batchProcessor(""), batchSize, maxWorkers, store, cursor)
+
+				Convey("Then only the batches after the checkpoint are processed and the checkpoint is cleared", func() {
+					So(err, ShouldBeNil)
+					So(batchGetterCalls, ShouldResemble, []int{6, 9})
+					So(batchProcessorCalls, ShouldResemble, []interface{}{
+						[]string{"6", "7", "8"},
+						[]string{"9"}})
+
+					checkpoint, err := store.Load()
+					So(err, ShouldBeNil)
+					So(checkpoint, ShouldBeNil)
+				})
+			})
+
+			Convey("And the process is resumed after the resource has changed", func() {
+				batchProcessorCalls = []interface{}{}
+				err := ProcessInConcurrentBatchesWithCheckpoint(batchGetter("newETag"), batchProcessor(""), batchSize, maxWorkers, store, cursor)
+
+				Convey("Then the expected error is returned without processing any batch, and the checkpoint is kept", func() {
+					So(errors.Is(err, ErrResourceChanged), ShouldBeTrue)
+					So(err.Error(), ShouldEqual, `resource changed since the last checkpoint: checkpoint eTag "testETag", current eTag "newETag"`)
+					So(batchProcessorCalls, ShouldBeEmpty)
+
+					checkpoint, err := store.Load()
+					So(err, ShouldBeNil)
+					So(checkpoint.Offset, ShouldEqual, 6)
+				})
+			})
+		})
+
+		Convey("When the process is resumed from a checkpoint with completed batches after its offset", func() {
+			store := &memoryCheckpointStore{saved: []Checkpoint{{Offset: 3, Completed: []int{6}, ETag: testETag}}}
+			err := ProcessInConcurrentBatchesWithCheckpoint(batchGetter(testETag), batchProcessor(""), batchSize, maxWorkers, store, nil)
+
+			Convey("Then the completed batches are not obtained or processed again, and the checkpoint is cleared", func() {
+				So(err, ShouldBeNil)
+				So(batchGetterCalls, ShouldResemble, []int{3, 9})
+				So(batchProcessorCalls, ShouldResemble, []interface{}{
+					[]string{"3", "4", "5"},
+					[]string{"9"}})
+				So(store.cleared, ShouldBeTrue)
+			})
+		})
+
+		Convey("When the process is resumed from a checkpoint with an offset beyond the total count of the resource", func() {
+			store := &memoryCheckpointStore{saved: []Checkpoint{{Offset: 12, ETag: testETag}}}
+			err := ProcessInConcurrentBatchesWithCheckpoint(batchGetter(testETag), batchProcessor(""), batchSize, maxWorkers, store, nil)
+
+			Convey("Then the expected error is returned without processing any batch, and the checkpoint is cleared", func() {
+				So(errors.Is(err, ErrResourceChanged), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "resource changed since the last checkpoint: checkpoint offset 12, current total count 10")
+				So(batchProcessorCalls, ShouldBeEmpty)
+				So(store.cleared, ShouldBeTrue)
+			})
+		})
+
+		Convey("Then calling ProcessInConcurrentBatchesWithCheckpoint with a nil store results in the expected error being returned", func() {
+			err := ProcessInConcurrentBatchesWithCheckpoint(batchGetter(testETag), batchProcessor(""), batchSize, maxWorkers, nil, nil)
+			So(err.Error(), ShouldEqual, "store cannot be nil")
+		})
+	})
+}
+
+func TestCheckpointTracker(t *testing.T) {
+
+	Convey("Given a checkpoint tracker for batches of size 3 out of 10 items", t, func() {
+		store := &memoryCheckpointStore{}
+		tracker := &checkpointTracker{store: store, batchSize: 3, processed: map[int]bool{}}
+
+		Convey("Then batches processed out of order only advance the checkpoint offset once all the previous batches are processed, "+
+			"and the batches processed after it are saved as completed", func() {
+			So(tracker.batchDone(3, 10, testETag), ShouldBeNil)
+			So(store.saved, ShouldResemble, []Checkpoint{{Offset: 0, Completed: []int{3}, ETag: testETag}})
+
+			So(tracker.batchDone(0, 10, testETag), ShouldBeNil)
+			So(store.saved[1], ShouldResemble, Checkpoint{Offset: 6, ETag: testETag})
+
+			So(tracker.batchDone(9, 10, testETag), ShouldBeNil)
+			So(store.saved[2], ShouldResemble, Checkpoint{Offset: 6, Completed: []int{9}, ETag: testETag})
+			So(store.cleared, ShouldBeFalse)
+
+			So(tracker.batchDone(6, 10, testETag), ShouldBeNil)
+			So(store.saved, ShouldHaveLength, 3)
+			So(store.cleared, ShouldBeTrue)
+		})
+	})
+}
+
+func TestFileCheckpointStore(t *testing.T) {
+
+	Convey("Given a file checkpoint store for a file that does not exist", t, func() {
+		path := filepath.Join(t.TempDir(), "checkpoint.json")
+		store := NewFileCheckpointStore(path)
+
+		Convey("Then loading returns no checkpoint and clearing is successful", func() {
+			checkpoint, err := store.Load()
+			So(err, ShouldBeNil)
+			So(checkpoint, ShouldBeNil)
+			So(store.Clear(), ShouldBeNil)
+		})
+
+		Convey("When a checkpoint is saved", func() {
+			So(store.Save(Checkpoint{Offset: 300, Cursor: "part-2", ETag: testETag}), ShouldBeNil)
+
+			Convey("Then it is loaded from the file and no temporary file is left", func() {
+				checkpoint, err := store.Load()
+				So(err, ShouldBeNil)
+				So(checkpoint, ShouldResemble, &Checkpoint{Offset: 300, Cursor: "part-2", ETag: testETag})
+
+				entries, err := os.ReadDir(filepath.Dir(path))
+				So(err, ShouldBeNil)
+				So(entries, ShouldHaveLength, 1)
+			})
+
+			Convey("Then clearing removes the file", func() {
+				So(store.Clear(), ShouldBeNil)
+				_, err := os.Stat(path)
+				So(errors.Is(err, os.ErrNotExist), ShouldBeTrue)
+			})
+		})
+
+		Convey("When the file contains invalid json, then loading fails", func() {
+			So(os.WriteFile(path, []byte("{"), 0600), ShouldBeNil)
+			_, err := store.Load()
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
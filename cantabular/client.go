@@ -9,6 +9,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
@@ -39,6 +40,9 @@ type Client struct {
 	host       string
 	extApiHost string
 	version    string
+
+	latencyThresholds health.LatencyThresholds
+	clock             func() time.Time
 }
 
 // NewClient returns a new Client
@@ -49,6 +53,8 @@ func NewClient(cfg Config, ua httpClient, g GraphQLClient) *Client {
 		host:       cfg.Host,
 		extApiHost: cfg.ExtApiHost,
 		version:    SoftwareVersion,
+
+		latencyThresholds: cfg.HealthLatencyThresholds,
 	}
 
 	if len(cfg.ExtApiHost) > 0 && c.gqlClient == nil {
@@ -149,7 +155,9 @@ func (c *Client) checkHealth(ctx context.Context, state *healthcheck.CheckState,
 	}
 	code := 0
 
+	start := c.now()
 	res, err := c.httpGet(ctx, reqURL)
+	latency := c.now().Sub(start)
 	defer closeResponseBody(ctx, res)
 
 	if err != nil {
@@ -162,12 +170,33 @@ func (c *Client) checkHealth(ctx context.Context, state *healthcheck.CheckState,
 	case 0: // When there is a problem with the client return error in message
 		return state.Update(healthcheck.StatusCritical, err.Error(), 0)
 	case 200:
-		message := service + health.StatusMessage[healthcheck.StatusOK]
-		return state.Update(healthcheck.StatusOK, message, code)
+		status := healthcheck.StatusOK
+		if c.latencyThresholds.Enabled() {
+			status = c.latencyThresholds.Status(latency)
+		}
+		return state.Update(status, c.healthMessage(service, status, latency), code)
 	default:
-		message := service + health.StatusMessage[healthcheck.StatusCritical]
-		return state.Update(healthcheck.StatusCritical, message, code)
+		return state.Update(healthcheck.StatusCritical, c.healthMessage(service, healthcheck.StatusCritical, latency), code)
+	}
+}
+
+// now returns the current time according to the clock of the client, which is used to measure latency.
+// A nil clock uses time.Now; tests provide a fake clock so that latency can be measured deterministically.
+func (c *Client) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock()
+}
+
+// healthMessage returns the health check message for the provided status,
+// including the measured latency if latency thresholds are configured
+func (c *Client) healthMessage(service, status string, latency time.Duration) string {
+	message := service + health.StatusMessage[status]
+	if c.latencyThresholds.Enabled() {
+		message += " - " + c.latencyThresholds.Message(latency)
 	}
+	return message
 }
 
 // errorResponse handles dealing with an error response from Cantabular
@@ -11,6 +11,7 @@ import (
 	. "github.com/smartystreets/goconvey/convey"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 )
@@ -243,6 +244,45 @@ func TestChecker(t *testing.T) {
 	})
 }
 
+func TestCheckerLatency(t *testing.T) {
+	testCtx := context.Background()
+
+	Convey("Given a slow Cantabular ext API and configured latency thresholds", t, func() {
+		now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+		mockHttpClient := dphttp.ClienterMock{
+			GetFunc: func(ctx context.Context, url string) (*http.Response, error) {
+				now = now.Add(20 * time.Millisecond)
+				return Response(nil, http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				ExtApiHost: "cantabular-ext-api-host",
+				HealthLatencyThresholds: health.LatencyThresholds{
+					Warning:  5 * time.Millisecond,
+					Critical: time.Second,
+				},
+			},
+			&mockHttpClient,
+			nil,
+		)
+		cantabularClient.SetClock(func() time.Time { return now })
+
+		Convey("When the CheckerApiExt method is called", func() {
+			check := healthcheck.NewCheckState(cantabular.ServiceAPIExt)
+			err := cantabularClient.CheckerAPIExt(testCtx, check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the CheckState is updated to a WARNING state with the measured latency", func() {
+				So(check.StatusCode(), ShouldEqual, 200)
+				So(check.Status(), ShouldEqual, healthcheck.StatusWarning)
+				So(check.Message(), ShouldEqual, "cantabularAPIExt is degraded, but at least partially functioning - latency: 20ms exceeds warning threshold of 5ms")
+			})
+		})
+	})
+}
+
 func TestStatusCode(t *testing.T) {
 	client := cantabular.NewClient(
 		cantabular.Config{},
@@ -35,7 +35,7 @@ func (c *Client) GetCodebook(ctx context.Context, req GetCodebookRequest) (*GetC
 	res, err := c.httpGet(ctx, url)
 	if err != nil {
 		return nil, dperrors.New(
-			fmt.Errorf("failed to get response from Cantabular API: %s", err),
+			fmt.Errorf("failed to get response from Cantabular API: %w", err),
 			http.StatusInternalServerError,
 			log.Data{
 				"url": url,
@@ -6,7 +6,9 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 	"net/http"
+	"syscall"
 	"testing"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
@@ -74,6 +76,41 @@ func TestGetCodebookUnhappy(t *testing.T) {
 				So(cb, ShouldBeNil)
 				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
 			})
+
+			Convey("Then the error is not temporary", func() {
+				So(dperrors.IsTemporary(err), ShouldBeFalse)
+			})
+		})
+	})
+
+	Convey("Given a Cantabular refuses the connection", t, func() {
+		testCtx := context.Background()
+
+		mockHttpClient := &dphttp.ClienterMock{
+			GetFunc: func(ctx context.Context, url string) (*http.Response, error) {
+				return nil, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When the GetCodebook method is called", func() {
+			req := cantabular.GetCodebookRequest{}
+			cb, err := cantabularClient.GetCodebook(testCtx, req)
+
+			Convey("Then the status code 500 should be recoverable from the error, and the error is temporary and retryable", func() {
+				So(cb, ShouldBeNil)
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
+				So(dperrors.IsTemporary(err), ShouldBeTrue)
+				So(dperrors.IsRetryableRequest(http.MethodGet, err), ShouldBeTrue)
+			})
 		})
 	})
 
@@ -2,6 +2,8 @@ package cantabular
 
 import (
 	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 )
 
 // Config holds the config used to initialise the Cantabular Client
@@ -9,4 +11,7 @@ type Config struct {
 	Host           string
 	ExtApiHost     string
 	GraphQLTimeout time.Duration
+
+	// HealthLatencyThresholds, if set, degrade the health check status when Cantabular responds slowly
+	HealthLatencyThresholds health.LatencyThresholds
 }
@@ -0,0 +1,153 @@
+package cantabular
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// CSVWContext is the JSON-LD context of CSVW metadata documents
+const CSVWContext = "http://www.w3.org/ns/csvw"
+
+// CSVWMetadata represents a W3C CSV on the Web (CSVW) metadata document describing a CSV file,
+// which is usually published alongside the CSV file with the same name followed by '-metadata.json'
+type CSVWMetadata struct {
+	Context      string            `json:"@context"`
+	URL          string            `json:"url"`
+	Title        string            `json:"dc:title,omitempty"`
+	Description  string            `json:"dc:description,omitempty"`
+	License      string            `json:"dc:license,omitempty"`
+	ContactPoint *CSVWContactPoint `json:"dcat:contactPoint,omitempty"`
+	TableSchema  CSVWTableSchema   `json:"tableSchema"`
+}
+
+// CSVWContactPoint represents the contact details of a CSVW metadata document
+type CSVWContactPoint struct {
+	Name    string `json:"vcard:fn,omitempty"`
+	Email   string `json:"vcard:hasEmail,omitempty"`
+	Phone   string `json:"vcard:hasTelephone,omitempty"`
+	Website string `json:"vcard:hasURL,omitempty"`
+}
+
+// CSVWTableSchema represents the schema of the table described by a CSVW metadata document
+type CSVWTableSchema struct {
+	Columns    []CSVWColumn `json:"columns"`
+	PrimaryKey []string     `json:"primaryKey,omitempty"`
+}
+
+// CSVWColumn represents a column of the table described by a CSVW metadata document
+type CSVWColumn struct {
+	Name        string `json:"name"`
+	Titles      string `json:"titles"`
+	Datatype    string `json:"datatype"`
+	Description string `json:"dc:description,omitempty"`
+}
+
+// GraphQLJSONToCSVWithMetadata converts a JSON response in r to CSV on w, like GraphQLJSONToCSV, returning the row count
+// and the CSVW metadata describing the CSV, which is published at the provided url.
+// The metadata has a column for the code and label of each dimension, named after its variable, and for the observation.
+// Its title and description are obtained from the table metadata and its licence from the dataset metadata of the provided metadata,
+// which may be nil, and the contact is obtained from either of them.
+// If no table cell values are present then no output is written and the returned metadata is nil.
+// If an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToCSVWithMetadata(ctx context.Context, r io.Reader, w io.Writer, url string, meta *MetadataQueryResult) (rowCount int32, csvw *CSVWMetadata, err error) {
+	cw := csv.NewWriter(w)
+	// csv.Writer errors are sticky, so we only need to check when flushing at the end
+	defer func() {
+		cw.Flush()
+		if cwErr := cw.Error(); cwErr != nil && err == nil {
+			csvw = nil
+			err = fmt.Errorf("csv writer error: %w", cwErr)
+		}
+	}()
+
+	mw := &csvwWriter{Writer: cw, url: url, meta: meta}
+	if rowCount, err = graphQLJSONToRows(ctx, r, mw); err != nil {
+		return 0, nil, err
+	}
+	return rowCount, mw.csvw, nil
+}
+
+// csvwWriter writes the rows of a table as CSV, and creates the CSVW metadata when the dimensions of the table are written
+type csvwWriter struct {
+	*csv.Writer
+	url  string
+	meta *MetadataQueryResult
+	csvw *CSVWMetadata
+}
+
+// WriteDimensions creates the CSVW metadata for the provided dimensions
+func (mw *csvwWriter) WriteDimensions(dims Dimensions) error {
+	mw.csvw = newCSVWMetadata(mw.url, dims, mw.meta)
+	return nil
+}
+
+// newCSVWMetadata creates the CSVW metadata for a CSV file with the provided url,
+// generated for the provided dimensions, with the details from the provided metadata
+func newCSVWMetadata(url string, dims Dimensions, meta *MetadataQueryResult) *CSVWMetadata {
+	m := &CSVWMetadata{
+		Context: CSVWContext,
+		URL:     url,
+	}
+
+	header := createCSVHeader(dims)
+	varDescriptions := map[string]string{}
+	observation := CSVWColumn{
+		Name:     "observation",
+		Titles:   header[len(header)-1],
+		Datatype: "number",
+	}
+
+	contact := &CSVWContactPoint{}
+	if meta != nil && meta.DatasetQueryResult != nil {
+		ds := meta.DatasetQueryResult.Dataset
+		m.License = string(ds.Meta.Source.Licence)
+		contact = &CSVWContactPoint{
+			Name:    string(ds.Meta.Source.Contact.ContactName),
+			Email:   string(ds.Meta.Source.Contact.ContactEmail),
+			Phone:   string(ds.Meta.Source.Contact.ContactPhone),
+			Website: string(ds.Meta.Source.Contact.ContactWebsite),
+		}
+		for _, v := range ds.Vars {
+			varDescriptions[string(v.Name)] = string(v.Description)
+		}
+	}
+	if meta != nil && meta.TableQueryResult != nil && len(meta.TableQueryResult.Service.Tables) > 0 {
+		t := meta.TableQueryResult.Service.Tables[0]
+		m.Title = string(t.Label)
+		m.Description = string(t.Description)
+		observation.Description = string(t.Meta.Observation_Type.Observation_Type_Description)
+		if t.Meta.Contact.ContactName != "" || t.Meta.Contact.ContactEmail != "" {
+			contact = &CSVWContactPoint{
+				Name:    string(t.Meta.Contact.ContactName),
+				Email:   string(t.Meta.Contact.ContactEmail),
+				Phone:   string(t.Meta.Contact.ContactPhone),
+				Website: string(t.Meta.Contact.ContactWebsite),
+			}
+		}
+	}
+	if *contact != (CSVWContactPoint{}) {
+		m.ContactPoint = contact
+	}
+
+	for i, dim := range dims {
+		code := dim.Variable.Name + "_code"
+		m.TableSchema.Columns = append(m.TableSchema.Columns,
+			CSVWColumn{
+				Name:     code,
+				Titles:   header[i*2],
+				Datatype: "string",
+			},
+			CSVWColumn{
+				Name:        dim.Variable.Name,
+				Titles:      header[i*2+1],
+				Datatype:    "string",
+				Description: varDescriptions[dim.Variable.Name],
+			},
+		)
+		m.TableSchema.PrimaryKey = append(m.TableSchema.PrimaryKey, code)
+	}
+	m.TableSchema.Columns = append(m.TableSchema.Columns, observation)
+	return m
+}
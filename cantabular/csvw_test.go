@@ -0,0 +1,130 @@
+package cantabular_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+func TestStreamCSVWithMetadata(t *testing.T) {
+	Convey("Given a stream consumer that reads the whole csv output", t, func() {
+		var out []byte
+		consume := func(ctx context.Context, r io.Reader) error {
+			var err error
+			out, err = io.ReadAll(r)
+			return err
+		}
+
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				return Response([]byte(mockRespBodyStaticDataset), http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		req := cantabular.StaticDatasetQueryRequest{
+			Dataset:   "Example",
+			Variables: []string{"city", "siblings"},
+		}
+		url := "https://download.ons.gov.uk/example.csv"
+
+		Convey("When the static dataset query is streamed as csv with table and dataset metadata", func() {
+			tableMeta := &cantabular.MetadataTableQuery{}
+			datasetMeta := &cantabular.MetadataDatasetQuery{}
+			So(json.Unmarshal([]byte(`{"service": {"tables": [{
+				"label": "Siblings by city",
+				"description": "Number of siblings of people living in each city",
+				"meta": {
+					"contact": {"contact_name": "Census team", "contact_email": "census@ons.gov.uk"},
+					"observation_type": {"observation_type_description": "Count of people"}
+				}
+			}]}}`), tableMeta), ShouldBeNil)
+			So(json.Unmarshal([]byte(`{"dataset": {
+				"meta": {"source": {"licence": "Open Government Licence v3.0"}},
+				"vars": [{"name": "city", "description": "The city where a person lives"}]
+			}}`), datasetMeta), ShouldBeNil)
+			meta := &cantabular.MetadataQueryResult{
+				TableQueryResult:   tableMeta,
+				DatasetQueryResult: datasetMeta,
+			}
+
+			rowCount, csvw, err := cantabularClient.StaticDatasetQueryStreamCSVWithMetadata(testCtx, req, url, meta, consume)
+
+			Convey("Then the expected CSV is streamed", func() {
+				So(err, ShouldBeNil)
+				So(rowCount, ShouldEqual, 22)
+				So(string(out), ShouldEqual, expectedCsv)
+			})
+
+			Convey("Then the returned CSVW metadata describes the CSV columns, with the details from the metadata", func() {
+				So(csvw, ShouldResemble, &cantabular.CSVWMetadata{
+					Context:     "http://www.w3.org/ns/csvw",
+					URL:         url,
+					Title:       "Siblings by city",
+					Description: "Number of siblings of people living in each city",
+					License:     "Open Government Licence v3.0",
+					ContactPoint: &cantabular.CSVWContactPoint{
+						Name:  "Census team",
+						Email: "census@ons.gov.uk",
+					},
+					TableSchema: cantabular.CSVWTableSchema{
+						Columns: []cantabular.CSVWColumn{
+							{Name: "city_code", Titles: "City Code", Datatype: "string"},
+							{Name: "city", Titles: "City", Datatype: "string", Description: "The city where a person lives"},
+							{Name: "siblings_code", Titles: "Number of siblings Code", Datatype: "string"},
+							{Name: "siblings", Titles: "Number of siblings", Datatype: "string"},
+							{Name: "observation", Titles: "Observation", Datatype: "number", Description: "Count of people"},
+						},
+						PrimaryKey: []string{"city_code", "siblings_code"},
+					},
+				})
+			})
+
+			Convey("Then the CSVW metadata is marshalled with the CSVW property names", func() {
+				b, err := json.Marshal(csvw)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldStartWith, `{"@context":"http://www.w3.org/ns/csvw","url":"https://download.ons.gov.uk/example.csv","dc:title":"Siblings by city"`)
+				So(string(b), ShouldContainSubstring, `"dcat:contactPoint":{"vcard:fn":"Census team","vcard:hasEmail":"census@ons.gov.uk"}`)
+			})
+		})
+
+		Convey("When the static dataset query is streamed as csv without metadata", func() {
+			_, csvw, err := cantabularClient.StaticDatasetQueryStreamCSVWithMetadata(testCtx, req, url, nil, consume)
+
+			Convey("Then the returned CSVW metadata only describes the CSV columns", func() {
+				So(err, ShouldBeNil)
+				So(csvw.Title, ShouldBeEmpty)
+				So(csvw.ContactPoint, ShouldBeNil)
+				So(csvw.TableSchema.Columns, ShouldHaveLength, 5)
+			})
+		})
+	})
+
+	Convey("Given a query response for a blocked table", t, func() {
+		out := &bytes.Buffer{}
+
+		Convey("Then converting it to csv with metadata fails with the expected error and no metadata", func() {
+			_, csvw, err := cantabular.GraphQLJSONToCSVWithMetadata(testCtx, strings.NewReader(mockRespBodyTableError), out, "", nil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "table blocked")
+			So(csvw, ShouldBeNil)
+		})
+	})
+}
@@ -0,0 +1,11 @@
+package cantabular
+
+import "time"
+
+// SetClock sets the clock used by the client to measure latency
+func (c *Client) SetClock(clock func() time.Time) {
+	c.clock = clock
+}
+
+// NewXLSXWriter creates a writer of XLSX workbooks, so that rows can be written directly in tests
+var NewXLSXWriter = newXLSXWriter
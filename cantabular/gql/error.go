@@ -11,24 +11,37 @@ type Error struct {
 	Path      []string   `json:"path"`
 }
 
+// Error returns the message of the GraphQL error, so that it can be handled as an error,
+// e.g. by dp-api-clients-go errors.IsRetryable
+func (e *Error) Error() string {
+	return e.Message
+}
+
 // StatusCode returns the status code defined at the begining of the Error message.
 // For example: a status 404 is extracted from '404 Not Found: dataset not loaded in this server'.
 // If no status code is provided, then a value of 502 bad gateway is returned.
 func (e *Error) StatusCode() int {
-	if len(e.Message) < 3 {
+	if !e.HasStatusCode() {
 		return http.StatusBadGateway
 	}
 
-	statusCode, err := strconv.Atoi(e.Message[:3])
-	if err != nil {
-		return http.StatusBadGateway
+	statusCode, _ := strconv.Atoi(e.Message[:3])
+	return statusCode
+}
+
+// HasStatusCode returns true if the Error message begins with a valid status code,
+// so that errors without one (e.g. query validation errors) are not considered temporary by errors.IsTemporary
+func (e *Error) HasStatusCode() bool {
+	if len(e.Message) < 3 {
+		return false
 	}
 
-	if http.StatusText(statusCode) == "" {
-		return http.StatusBadGateway
+	statusCode, err := strconv.Atoi(e.Message[:3])
+	if err != nil {
+		return false
 	}
 
-	return statusCode
+	return http.StatusText(statusCode) != ""
 }
 
 // 404 Not Found: dataset not loaded in this server
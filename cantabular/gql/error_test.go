@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -31,3 +32,24 @@ func TestChecker(t *testing.T) {
 	})
 
 }
+
+func TestRetryable(t *testing.T) {
+
+	Convey("An error with a temporary status code is retryable", t, func() {
+		err := &gql.Error{Message: "503 Service Unavailable: server is loading datasets"}
+		So(err.Error(), ShouldEqual, "503 Service Unavailable: server is loading datasets")
+		So(dperrors.IsRetryable(err), ShouldBeTrue)
+	})
+
+	Convey("An error with a client error status code is not retryable", t, func() {
+		err := &gql.Error{Message: "404 Not Found: dataset not loaded in this server"}
+		So(dperrors.IsRetryable(err), ShouldBeFalse)
+	})
+	Convey("An error without a status code is not retryable", t, func() {
+		err := &gql.Error{Message: "Cannot query field \"foo\" on type \"Dataset\""}
+		So(err.HasStatusCode(), ShouldBeFalse)
+		So(err.StatusCode(), ShouldEqual, http.StatusBadGateway)
+		So(dperrors.IsTemporary(err), ShouldBeFalse)
+		So(dperrors.IsRetryable(err), ShouldBeFalse)
+	})
+}
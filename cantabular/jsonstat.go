@@ -0,0 +1,115 @@
+package cantabular
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream/jsonstream"
+)
+
+// JSONStatDimension represents a dimension of a JSON-stat dataset
+type JSONStatDimension struct {
+	Label    string           `json:"label"`
+	Category JSONStatCategory `json:"category"`
+}
+
+// JSONStatCategory represents the categories of a JSON-stat dimension
+type JSONStatCategory struct {
+	Index []string          `json:"index"`
+	Label map[string]string `json:"label"`
+}
+
+// GraphQLJSONToJSONStat converts a JSON response in r to a JSON-stat 2.0 dataset on w, returning the number of observations,
+// which are written as the values of the dataset.
+// The dataset has a dimension for each variable, with the variable name as id and its categories, in the order of the table,
+// as index and label. The values are written to w in row-major order as they are decoded.
+// If no table cell values are present then no output is written.
+// If an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToJSONStat(ctx context.Context, r io.Reader, w io.Writer) (int32, error) {
+	jw := &jsonStatWriter{enc: jsonstream.NewEncoder(w)}
+	rowCount, err := graphQLJSONToRows(ctx, r, jw)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := jw.Close(); err != nil {
+		return 0, fmt.Errorf("json-stat writer error: %w", err)
+	}
+	return observationCount(rowCount), nil
+}
+
+// jsonStatWriter writes the rows of a table as the values of a JSON-stat dataset
+type jsonStatWriter struct {
+	enc     *jsonstream.Encoder
+	started bool
+	header  bool
+}
+
+// WriteDimensions writes the ids, sizes and dimensions of the dataset, and the start of its values
+func (jw *jsonStatWriter) WriteDimensions(dims Dimensions) error {
+	ids := make([]string, len(dims))
+	sizes := make([]int, len(dims))
+	dimensions := make(map[string]JSONStatDimension, len(dims))
+	for i, dim := range dims {
+		ids[i] = dim.Variable.Name
+		sizes[i] = len(dim.Categories)
+		category := JSONStatCategory{
+			Index: make([]string, len(dim.Categories)),
+			Label: make(map[string]string, len(dim.Categories)),
+		}
+		for j, c := range dim.Categories {
+			category.Index[j] = c.Code
+			category.Label[c.Code] = c.Label
+		}
+		dimensions[dim.Variable.Name] = JSONStatDimension{
+			Label:    dim.Variable.Label,
+			Category: category,
+		}
+	}
+
+	jw.started = true
+	if err := jw.enc.StartObject(); err != nil {
+		return err
+	}
+	fields := []struct {
+		name  string
+		value interface{}
+	}{
+		{"version", "2.0"},
+		{"class", "dataset"},
+		{"id", ids},
+		{"size", sizes},
+		{"dimension", dimensions},
+	}
+	for _, f := range fields {
+		if err := jw.enc.Field(f.name, f.value); err != nil {
+			return err
+		}
+	}
+	return jw.enc.StartArrayField("value")
+}
+
+// Write writes the observation of a table row as the next value of the dataset.
+// The first row is the CSV header of the table, which is not written.
+func (jw *jsonStatWriter) Write(row []string) error {
+	if !jw.header {
+		jw.header = true
+		return nil
+	}
+	return jw.enc.Encode(json.Number(row[len(row)-1]))
+}
+
+// Close ends the dataset, if it was started
+func (jw *jsonStatWriter) Close() error {
+	if jw.started {
+		if err := jw.enc.EndArray(); err != nil {
+			return err
+		}
+		if err := jw.enc.EndObject(); err != nil {
+			return err
+		}
+	}
+	return jw.enc.Close()
+}
@@ -0,0 +1,97 @@
+package cantabular_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+func TestStreamJSONStat(t *testing.T) {
+	Convey("Given a stream consumer that reads the whole json-stat output", t, func() {
+		var out []byte
+		consume := func(ctx context.Context, r io.Reader) error {
+			var err error
+			out, err = io.ReadAll(r)
+			return err
+		}
+
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				return Response([]byte(mockRespBodyStaticDataset), http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When the static dataset query is streamed as json-stat", func() {
+			req := cantabular.StaticDatasetQueryRequest{
+				Dataset:   "Example",
+				Variables: []string{"city", "siblings"},
+			}
+			observations, err := cantabularClient.StaticDatasetQueryStreamJSONStat(testCtx, req, consume)
+
+			Convey("Then the consumer receives a json-stat dataset with the dimensions and values of the table", func() {
+				So(err, ShouldBeNil)
+				So(observations, ShouldEqual, 21)
+
+				var dataset struct {
+					Version   string                                  `json:"version"`
+					Class     string                                  `json:"class"`
+					ID        []string                                `json:"id"`
+					Size      []int                                   `json:"size"`
+					Dimension map[string]cantabular.JSONStatDimension `json:"dimension"`
+					Value     []float64                               `json:"value"`
+				}
+				So(json.Unmarshal(out, &dataset), ShouldBeNil)
+				So(dataset.Version, ShouldEqual, "2.0")
+				So(dataset.Class, ShouldEqual, "dataset")
+				So(dataset.ID, ShouldResemble, []string{"city", "siblings"})
+				So(dataset.Size, ShouldResemble, []int{3, 7})
+				So(dataset.Dimension["city"], ShouldResemble, cantabular.JSONStatDimension{
+					Label: "City",
+					Category: cantabular.JSONStatCategory{
+						Index: []string{"0", "1", "2"},
+						Label: map[string]string{"0": "London", "1": "Liverpool", "2": "Belfast"},
+					},
+				})
+				So(dataset.Value, ShouldResemble, []float64{1, 0, 0, 1, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0, 0, 1, 0, 0, 1, 1})
+			})
+		})
+	})
+
+	Convey("Given a query response for a blocked table", t, func() {
+		out := &bytes.Buffer{}
+
+		Convey("Then converting it to json-stat fails with the expected error and nothing is written", func() {
+			_, err := cantabular.GraphQLJSONToJSONStat(testCtx, strings.NewReader(mockRespBodyTableError), out)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "table blocked")
+			So(out.Len(), ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given a query response converted to json-stat on a writer that fails", t, func() {
+		_, err := cantabular.GraphQLJSONToJSONStat(testCtx, strings.NewReader(mockRespBodyStaticDataset), failingWriter{})
+
+		Convey("Then the encoding error is returned when the dimensions are written", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "error writing dimensions: error writing json: write failed")
+		})
+	})
+}
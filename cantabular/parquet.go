@@ -0,0 +1,159 @@
+package cantabular
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// DefaultParquetRowGroupSize is the default maximum number of rows in each row group of a Parquet file
+const DefaultParquetRowGroupSize = 1 << 20
+
+// GraphQLJSONToParquet converts a JSON response in r to a Parquet file on w, returning the number of observations.
+// The file has a dictionary encoded string column for each category code and label column of the CSV generated by GraphQLJSONToCSV,
+// named after its header in snake case, and a double 'observation' column.
+// The rows are written in row groups of at most rowGroupSize rows, or DefaultParquetRowGroupSize if rowGroupSize is not positive,
+// so that only one row group is held in memory at a time.
+// If no table cell values are present then no output is written.
+// If an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToParquet(ctx context.Context, r io.Reader, w io.Writer, rowGroupSize int) (int32, error) {
+	if rowGroupSize <= 0 {
+		rowGroupSize = DefaultParquetRowGroupSize
+	}
+	pw := &parquetWriter{
+		w:            w,
+		rowGroupSize: rowGroupSize,
+	}
+
+	rowCount, err := graphQLJSONToRows(ctx, r, pw)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := pw.Close(); err != nil {
+		return 0, fmt.Errorf("parquet writer error: %w", err)
+	}
+	return observationCount(rowCount), nil
+}
+
+// parquetWriter writes the rows of a table as a Parquet file. The first row is the header, which defines the schema of the file,
+// and the following rows are buffered by the underlying parquet.Writer until a row group is complete.
+type parquetWriter struct {
+	w            io.Writer
+	rowGroupSize int
+	pw           *parquet.Writer
+	columns      int
+	row          parquet.Row
+}
+
+// Write writes a row of the table. The last column of the rows after the header is the observation, which is written as a double.
+func (pw *parquetWriter) Write(row []string) error {
+	if pw.pw == nil {
+		return pw.writeHeader(row)
+	}
+	if len(row) != pw.columns {
+		return fmt.Errorf("wrong number of fields in row: expected %d, got %d", pw.columns, len(row))
+	}
+
+	pw.row = pw.row[:0]
+	last := len(row) - 1
+	for i, field := range row[:last] {
+		pw.row = append(pw.row, parquet.ByteArrayValue([]byte(field)).Level(0, 0, i))
+	}
+	observation, err := strconv.ParseFloat(row[last], 64)
+	if err != nil {
+		return fmt.Errorf("invalid observation: %w", err)
+	}
+	pw.row = append(pw.row, parquet.DoubleValue(observation).Level(0, 0, last))
+
+	_, err = pw.pw.WriteRows([]parquet.Row{pw.row})
+	return err
+}
+
+// Close writes any buffered rows and the file footer. It does not close the underlying writer.
+func (pw *parquetWriter) Close() error {
+	if pw.pw == nil {
+		return nil
+	}
+	return pw.pw.Close()
+}
+
+// writeHeader defines the schema of the file, with a column for each header field
+func (pw *parquetWriter) writeHeader(header []string) error {
+	if len(header) == 0 {
+		return errors.New("parquet header cannot be empty")
+	}
+
+	group := parquet.Group{}
+	counts := map[string]int{}
+	names := make([]string, len(header))
+	for i, field := range header {
+		name := parquetColumnName(field)
+		if counts[name]++; counts[name] > 1 {
+			name = fmt.Sprintf("%s_%d", name, counts[name])
+		}
+		names[i] = name
+		if i == len(header)-1 {
+			group[name] = parquet.Leaf(parquet.DoubleType)
+		} else {
+			group[name] = parquet.Encoded(parquet.String(), &parquet.RLEDictionary)
+		}
+	}
+
+	schema := parquet.NewSchema("table", newParquetColumns(group, names))
+	pw.pw = parquet.NewWriter(pw.w, schema, parquet.MaxRowsPerRowGroup(int64(pw.rowGroupSize)))
+	pw.columns = len(header)
+	return nil
+}
+
+// parquetColumns is a parquet.Group whose fields are in the provided order, instead of sorted by name,
+// so that the columns of the file are in the same order as the header
+type parquetColumns struct {
+	parquet.Group
+	fields []parquet.Field
+}
+
+// newParquetColumns returns the fields of the provided group in the order of the provided names
+func newParquetColumns(group parquet.Group, names []string) *parquetColumns {
+	byName := map[string]parquet.Field{}
+	for _, f := range group.Fields() {
+		byName[f.Name()] = f
+	}
+
+	c := &parquetColumns{Group: group, fields: make([]parquet.Field, len(names))}
+	for i, name := range names {
+		c.fields[i] = byName[name]
+	}
+	return c
+}
+
+// Fields returns the fields of the group in the order of the header
+func (c *parquetColumns) Fields() []parquet.Field {
+	return c.fields
+}
+
+// parquetColumnName returns the provided header field in snake case, e.g. 'number_of_siblings_code' for 'Number of siblings Code'
+func parquetColumnName(field string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(field) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+		} else {
+			underscore = true
+		}
+	}
+	if b.Len() == 0 {
+		return "column"
+	}
+	return b.String()
+}
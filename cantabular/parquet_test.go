@@ -0,0 +1,160 @@
+package cantabular_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/format"
+)
+
+func TestStreamParquet(t *testing.T) {
+	Convey("Given a stream consumer that reads the whole parquet output", t, func() {
+		var out []byte
+		consume := func(ctx context.Context, r io.Reader) error {
+			var err error
+			out, err = io.ReadAll(r)
+			return err
+		}
+
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				return Response([]byte(mockRespBodyStaticDataset), http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		Convey("When the static dataset query is streamed as parquet", func() {
+			req := cantabular.StaticDatasetQueryRequest{
+				Dataset:   "Example",
+				Variables: []string{"city", "siblings"},
+			}
+			rowCount, err := cantabularClient.StaticDatasetQueryStreamParquet(testCtx, req, consume)
+
+			Convey("Then the consumer receives a parquet file with a single row group containing the same rows as the CSV output", func() {
+				So(err, ShouldBeNil)
+				So(rowCount, ShouldEqual, 21)
+
+				columns, rows, rowGroups := readParquet(out)
+				So(columns, ShouldResemble, []string{"city_code", "city", "number_of_siblings_code", "number_of_siblings", "observation"})
+				So(rowGroups, ShouldEqual, 1)
+				So(toCSV(columns, rows), ShouldEqual, strings.Replace(expectedCsv,
+					"City Code,City,Number of siblings Code,Number of siblings,Observation",
+					"city_code,city,number_of_siblings_code,number_of_siblings,observation", 1))
+			})
+		})
+	})
+
+	Convey("Given a query response for a table with long runs of repeated categories", t, func() {
+		var codes, values []string
+		for i := 0; i < 20; i++ {
+			codes = append(codes, fmt.Sprintf(`{"code": "%d", "label": "Age %d"}`, i, i))
+		}
+		for i := 0; i < 60; i++ {
+			values = append(values, strconv.Itoa(i))
+		}
+		body := fmt.Sprintf(`{"data": {"dataset": {"table": {"dimensions": [
+			{"categories": [{"code": "E1", "label": "England"}, {"code": "W1", "label": "Wales"}, {"code": "S1", "label": "Scotland"}], "count": 3, "variable": {"label": "Country", "name": "country"}},
+			{"categories": [%s], "count": 20, "variable": {"label": "Age", "name": "age"}}
+		], "error": null, "values": [%s]}}}}`, strings.Join(codes, ","), strings.Join(values, ","))
+
+		Convey("When it is converted to parquet with row groups of 25 rows", func() {
+			out := &bytes.Buffer{}
+			rowCount, err := cantabular.GraphQLJSONToParquet(testCtx, strings.NewReader(body), out, 25)
+			So(err, ShouldBeNil)
+			So(rowCount, ShouldEqual, 60)
+
+			Convey("Then the file has 3 row groups with all the rows in order", func() {
+				columns, rows, rowGroups := readParquet(out.Bytes())
+				So(columns, ShouldResemble, []string{"country_code", "country", "age_code", "age", "observation"})
+				So(rowGroups, ShouldEqual, 3)
+				So(rows, ShouldHaveLength, 60)
+				So(rows[0], ShouldResemble, []string{"E1", "England", "0", "Age 0", "0"})
+				So(rows[24], ShouldResemble, []string{"W1", "Wales", "4", "Age 4", "24"})
+				So(rows[59], ShouldResemble, []string{"S1", "Scotland", "19", "Age 19", "59"})
+			})
+		})
+	})
+
+	Convey("Given a query response for a blocked table", t, func() {
+		out := &bytes.Buffer{}
+
+		Convey("Then converting it to parquet fails with the expected error and nothing is written", func() {
+			_, err := cantabular.GraphQLJSONToParquet(testCtx, strings.NewReader(mockRespBodyTableError), out, 0)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "table blocked")
+			So(out.Len(), ShouldEqual, 0)
+		})
+	})
+}
+
+// readParquet reads a parquet file generated by GraphQLJSONToParquet with the parquet-go reader,
+// returning the column names, the rows as strings and the number of row groups
+func readParquet(b []byte) (columns []string, rows [][]string, rowGroups int) {
+	f, err := parquet.OpenFile(bytes.NewReader(b), int64(len(b)))
+	So(err, ShouldBeNil)
+
+	for _, field := range f.Schema().Fields() {
+		columns = append(columns, field.Name())
+	}
+
+	for _, rg := range f.Metadata().RowGroups {
+		rowGroups++
+		for _, chunk := range rg.Columns[:len(columns)-1] {
+			So(chunk.MetaData.Type, ShouldEqual, format.ByteArray)
+			So(chunk.MetaData.Encoding, ShouldContain, format.RLEDictionary)
+		}
+		So(rg.Columns[len(columns)-1].MetaData.Type, ShouldEqual, format.Double)
+	}
+
+	r := parquet.NewReader(bytes.NewReader(b))
+	defer r.Close()
+	buf := make([]parquet.Row, 10)
+	for {
+		n, err := r.ReadRows(buf)
+		for _, row := range buf[:n] {
+			values := make([]string, len(row))
+			for i, v := range row {
+				if v.Kind() == parquet.Double {
+					values[i] = strconv.FormatFloat(v.Double(), 'f', -1, 64)
+				} else {
+					values[i] = v.String()
+				}
+			}
+			rows = append(rows, values)
+		}
+		if err == io.EOF {
+			break
+		}
+		So(err, ShouldBeNil)
+	}
+	So(f.NumRows(), ShouldEqual, len(rows))
+	return columns, rows, rowGroups
+}
+
+// toCSV returns the provided header and rows as comma separated lines
+func toCSV(header []string, rows [][]string) string {
+	s := strings.Join(header, ",") + "\n"
+	for _, row := range rows {
+		s += strings.Join(row, ",") + "\n"
+	}
+	return s
+}
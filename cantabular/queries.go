@@ -676,7 +676,7 @@ func (c *Client) queryUnmarshal(ctx context.Context, graphQLQuery string, data Q
 	res, err := c.postQuery(ctx, graphQLQuery, data)
 	if err != nil {
 		return dperrors.New(
-			fmt.Errorf("failed to post query: %s", err),
+			fmt.Errorf("failed to post query: %w", err),
 			http.StatusInternalServerError,
 			logData,
 		)
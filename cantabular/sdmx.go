@@ -0,0 +1,318 @@
+package cantabular
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream/jsonstream"
+)
+
+// SDMXFormat is the format of an SDMX data message
+type SDMXFormat string
+
+// Possible values for SDMX formats
+const (
+	SDMXCSV  SDMXFormat = "csv"
+	SDMXJSON SDMXFormat = "json"
+)
+
+// Possible values for the type of an SDMX structure
+const (
+	SDMXDataflow           = "dataflow"
+	SDMXDataStructure      = "datastructure"
+	SDMXProvisionAgreement = "dataprovision"
+)
+
+// sdmxURNPrefixes are the URN prefixes of each type of SDMX structure
+var sdmxURNPrefixes = map[string]string{
+	SDMXDataflow:           "urn:sdmx:org.sdmx.infomodel.datastructure.Dataflow=",
+	SDMXDataStructure:      "urn:sdmx:org.sdmx.infomodel.datastructure.DataStructure=",
+	SDMXProvisionAgreement: "urn:sdmx:org.sdmx.infomodel.registry.ProvisionAgreement=",
+}
+
+// SDMXStructure is the reference to the SDMX structure that the data messages conform to, e.g. the dataflow 'ONS:TS009(1.0)'
+type SDMXStructure struct {
+	// Type is the type of structure: SDMXDataflow, SDMXDataStructure or SDMXProvisionAgreement. Defaults to SDMXDataflow if empty.
+	Type     string `json:"type"`
+	AgencyID string `json:"agency_id"`
+	ID       string `json:"id"`
+	// Version is the version of the structure. Defaults to '1.0' if empty.
+	Version string `json:"version"`
+}
+
+// Validate checks that the structure has an agency, an ID and a valid type
+func (s SDMXStructure) Validate() error {
+	if s.AgencyID == "" || s.ID == "" {
+		return errors.New("sdmx structure agency and id must be provided")
+	}
+	if _, ok := sdmxURNPrefixes[s.structureType()]; !ok {
+		return fmt.Errorf("invalid sdmx structure type: %q", s.Type)
+	}
+	return nil
+}
+
+// String returns the SDMX reference to the structure, in the format 'AGENCY:ID(VERSION)'
+func (s SDMXStructure) String() string {
+	version := s.Version
+	if version == "" {
+		version = "1.0"
+	}
+	return fmt.Sprintf("%s:%s(%s)", s.AgencyID, s.ID, version)
+}
+
+// URN returns the SDMX URN of the structure
+func (s SDMXStructure) URN() string {
+	return sdmxURNPrefixes[s.structureType()] + s.String()
+}
+
+func (s SDMXStructure) structureType() string {
+	if s.Type == "" {
+		return SDMXDataflow
+	}
+	return s.Type
+}
+
+// GraphQLJSONToSDMXCSV converts a JSON response in r to an SDMX-CSV 2.0 data message on w, returning the number of observations,
+// which is the row count excluding the header, so that it matches the count returned by GraphQLJSONToSDMXJSON.
+// The message has a column for each dimension, named after the variable name and containing the category codes, followed by an 'OBS_VALUE' column,
+// and each row references the provided structure.
+// If no table cell values are present then no output is written.
+// If an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToSDMXCSV(ctx context.Context, r io.Reader, w io.Writer, structure SDMXStructure) (rowCount int32, err error) {
+	if err := structure.Validate(); err != nil {
+		return 0, err
+	}
+
+	cw := csv.NewWriter(w)
+	// csv.Writer errors are sticky, so we only need to check when flushing at the end
+	defer func() {
+		cw.Flush()
+		if cwErr := cw.Error(); cwErr != nil && err == nil {
+			err = fmt.Errorf("csv writer error: %w", cwErr)
+		}
+	}()
+
+	if rowCount, err = graphQLJSONToRows(ctx, r, &sdmxCSVWriter{cw: cw, structure: structure}); err != nil {
+		return 0, err
+	}
+	return observationCount(rowCount), nil
+}
+
+// GraphQLJSONToSDMXJSON converts a JSON response in r to an SDMX-JSON 2.0 data message on w, returning the number of observations.
+// The structure of the message describes each dimension, with the variable name as id and its categories as values, and references
+// the provided structure. The observations of the data set are keyed by the positions of their categories in each dimension and
+// are written to w as they are decoded.
+// If no table cell values are present then no output is written.
+// If an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToSDMXJSON(ctx context.Context, r io.Reader, w io.Writer, structure SDMXStructure) (int32, error) {
+	if err := structure.Validate(); err != nil {
+		return 0, err
+	}
+
+	sw := &sdmxJSONWriter{enc: jsonstream.NewEncoder(w), structure: structure}
+	rowCount, err := graphQLJSONToRows(ctx, r, sw)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := sw.Close(); err != nil {
+		return 0, fmt.Errorf("sdmx json writer error: %w", err)
+	}
+	return observationCount(rowCount), nil
+}
+
+// sdmxCSVWriter writes the rows of a table as SDMX-CSV records
+type sdmxCSVWriter struct {
+	cw        *csv.Writer
+	structure SDMXStructure
+	header    bool
+	record    []string
+}
+
+// WriteDimensions writes the SDMX-CSV header, with a column for each dimension
+func (sw *sdmxCSVWriter) WriteDimensions(dims Dimensions) error {
+	header := []string{"STRUCTURE", "STRUCTURE_ID", "ACTION"}
+	for _, dim := range dims {
+		header = append(header, dim.Variable.Name)
+	}
+	sw.record = make([]string, len(header)+1)
+	return sw.cw.Write(append(header, "OBS_VALUE"))
+}
+
+// Write writes the category codes and the observation of a table row as an SDMX-CSV record.
+// The first row is the CSV header of the table, which is replaced by the header written by WriteDimensions.
+func (sw *sdmxCSVWriter) Write(row []string) error {
+	if !sw.header {
+		sw.header = true
+		return nil
+	}
+
+	sw.record[0] = sw.structure.structureType()
+	sw.record[1] = sw.structure.String()
+	sw.record[2] = "I"
+	for i := 0; i < len(row)/2; i++ {
+		sw.record[3+i] = row[i*2]
+	}
+	sw.record[len(sw.record)-1] = row[len(row)-1]
+	return sw.cw.Write(sw.record)
+}
+
+// sdmxJSONWriter writes the rows of a table as the observations of an SDMX-JSON data message
+type sdmxJSONWriter struct {
+	enc       *jsonstream.Encoder
+	structure SDMXStructure
+	started   bool
+	header    bool
+	positions []map[string]int
+}
+
+type sdmxMeta struct {
+	ID       string    `json:"id"`
+	Test     bool      `json:"test"`
+	Prepared string    `json:"prepared"`
+	Sender   sdmxParty `json:"sender"`
+}
+
+type sdmxParty struct {
+	ID string `json:"id"`
+}
+
+type sdmxStructure struct {
+	Structure  string         `json:"structure"`
+	Dimensions sdmxDimensions `json:"dimensions"`
+	Measures   sdmxMeasures   `json:"measures"`
+}
+
+type sdmxDimensions struct {
+	DataSet     []sdmxComponent `json:"dataSet"`
+	Series      []sdmxComponent `json:"series"`
+	Observation []sdmxComponent `json:"observation"`
+}
+
+type sdmxMeasures struct {
+	Observation []sdmxComponent `json:"observation"`
+}
+
+type sdmxComponent struct {
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	KeyPosition *int        `json:"keyPosition,omitempty"`
+	Values      []sdmxValue `json:"values,omitempty"`
+}
+
+type sdmxValue struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WriteDimensions writes the meta and structure of the data message, and the start of the observations of its data set
+func (sw *sdmxJSONWriter) WriteDimensions(dims Dimensions) error {
+	structure := sdmxStructure{
+		Structure: sw.structure.URN(),
+		Dimensions: sdmxDimensions{
+			DataSet:     []sdmxComponent{},
+			Series:      []sdmxComponent{},
+			Observation: make([]sdmxComponent, len(dims)),
+		},
+		Measures: sdmxMeasures{
+			Observation: []sdmxComponent{{ID: "OBS_VALUE", Name: "Observation"}},
+		},
+	}
+
+	sw.positions = make([]map[string]int, len(dims))
+	for i, dim := range dims {
+		keyPosition := i
+		values := make([]sdmxValue, len(dim.Categories))
+		sw.positions[i] = make(map[string]int, len(dim.Categories))
+		for j, c := range dim.Categories {
+			values[j] = sdmxValue{ID: c.Code, Name: c.Label}
+			sw.positions[i][c.Code] = j
+		}
+		structure.Dimensions.Observation[i] = sdmxComponent{
+			ID:          dim.Variable.Name,
+			Name:        dim.Variable.Label,
+			KeyPosition: &keyPosition,
+			Values:      values,
+		}
+	}
+
+	sw.started = true
+	if err := sw.enc.StartObject(); err != nil {
+		return err
+	}
+	meta := sdmxMeta{
+		ID:       fmt.Sprintf("%s-%d", sw.structure.ID, time.Now().UnixNano()),
+		Prepared: time.Now().UTC().Format(time.RFC3339),
+		Sender:   sdmxParty{ID: sw.structure.AgencyID},
+	}
+	if err := sw.enc.Field("meta", meta); err != nil {
+		return err
+	}
+	if err := sw.enc.StartObjectField("data"); err != nil {
+		return err
+	}
+	if err := sw.enc.Field("structures", []sdmxStructure{structure}); err != nil {
+		return err
+	}
+	if err := sw.enc.StartArrayField("dataSets"); err != nil {
+		return err
+	}
+	if err := sw.enc.StartObject(); err != nil {
+		return err
+	}
+	if err := sw.enc.Field("structure", 0); err != nil {
+		return err
+	}
+	if err := sw.enc.Field("action", "Information"); err != nil {
+		return err
+	}
+	return sw.enc.StartObjectField("observations")
+}
+
+// Write writes the observation of a table row, keyed by the positions of its categories.
+// The first row is the CSV header of the table, which is not written.
+func (sw *sdmxJSONWriter) Write(row []string) error {
+	if !sw.header {
+		sw.header = true
+		return nil
+	}
+
+	key := make([]string, len(sw.positions))
+	for i, positions := range sw.positions {
+		position, ok := positions[row[i*2]]
+		if !ok {
+			return fmt.Errorf("category %q not found in dimension %d", row[i*2], i)
+		}
+		key[i] = strconv.Itoa(position)
+	}
+	return sw.enc.Field(strings.Join(key, ":"), []json.Number{json.Number(row[len(row)-1])})
+}
+
+// Close ends the data message, if it was started
+func (sw *sdmxJSONWriter) Close() error {
+	if sw.started {
+		if err := sw.enc.EndObject(); err != nil { // observations
+			return err
+		}
+		if err := sw.enc.EndObject(); err != nil { // data set
+			return err
+		}
+		if err := sw.enc.EndArray(); err != nil { // dataSets
+			return err
+		}
+		if err := sw.enc.EndObject(); err != nil { // data
+			return err
+		}
+		if err := sw.enc.EndObject(); err != nil {
+			return err
+		}
+	}
+	return sw.enc.Close()
+}
@@ -0,0 +1,139 @@
+package cantabular_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+func TestStreamSDMX(t *testing.T) {
+	Convey("Given a stream consumer that reads the whole output", t, func() {
+		var out []byte
+		consume := func(ctx context.Context, r io.Reader) error {
+			var err error
+			out, err = io.ReadAll(r)
+			return err
+		}
+
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				return Response([]byte(mockRespBodyStaticDataset), http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		req := cantabular.StaticDatasetQueryRequest{
+			Dataset:   "Example",
+			Variables: []string{"city", "siblings"},
+		}
+		structure := cantabular.SDMXStructure{AgencyID: "ONS", ID: "EXAMPLE"}
+
+		Convey("When the static dataset query is streamed as SDMX-CSV", func() {
+			observations, err := cantabularClient.StaticDatasetQueryStreamSDMX(testCtx, req, cantabular.SDMXCSV, structure, consume)
+
+			Convey("Then the consumer receives an SDMX-CSV message with the category codes of each row", func() {
+				So(err, ShouldBeNil)
+				So(observations, ShouldEqual, 21)
+
+				lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+				So(lines, ShouldHaveLength, 22)
+				So(lines[0], ShouldEqual, "STRUCTURE,STRUCTURE_ID,ACTION,city,siblings,OBS_VALUE")
+				So(lines[1], ShouldEqual, "dataflow,ONS:EXAMPLE(1.0),I,0,0,1")
+				So(lines[21], ShouldEqual, "dataflow,ONS:EXAMPLE(1.0),I,2,6,1")
+			})
+		})
+
+		Convey("When the static dataset query is streamed as SDMX-JSON for a data structure", func() {
+			structure.Type = cantabular.SDMXDataStructure
+			structure.Version = "2.1"
+			observations, err := cantabularClient.StaticDatasetQueryStreamSDMX(testCtx, req, cantabular.SDMXJSON, structure, consume)
+
+			Convey("Then the consumer receives an SDMX-JSON message with the structure and all the observations", func() {
+				So(err, ShouldBeNil)
+				So(observations, ShouldEqual, 21)
+
+				var msg struct {
+					Meta struct {
+						Sender struct {
+							ID string `json:"id"`
+						} `json:"sender"`
+					} `json:"meta"`
+					Data struct {
+						Structures []struct {
+							Structure  string `json:"structure"`
+							Dimensions struct {
+								Observation []struct {
+									ID          string `json:"id"`
+									KeyPosition int    `json:"keyPosition"`
+									Values      []struct {
+										ID   string `json:"id"`
+										Name string `json:"name"`
+									} `json:"values"`
+								} `json:"observation"`
+							} `json:"dimensions"`
+						} `json:"structures"`
+						DataSets []struct {
+							Observations map[string][]float64 `json:"observations"`
+						} `json:"dataSets"`
+					} `json:"data"`
+				}
+				So(json.Unmarshal(out, &msg), ShouldBeNil)
+				So(msg.Meta.Sender.ID, ShouldEqual, "ONS")
+
+				So(msg.Data.Structures, ShouldHaveLength, 1)
+				So(msg.Data.Structures[0].Structure, ShouldEqual, "urn:sdmx:org.sdmx.infomodel.datastructure.DataStructure=ONS:EXAMPLE(2.1)")
+				dims := msg.Data.Structures[0].Dimensions.Observation
+				So(dims, ShouldHaveLength, 2)
+				So(dims[1].ID, ShouldEqual, "siblings")
+				So(dims[1].KeyPosition, ShouldEqual, 1)
+				So(dims[1].Values, ShouldHaveLength, 7)
+				So(dims[1].Values[3].Name, ShouldEqual, "3 siblings")
+
+				So(msg.Data.DataSets, ShouldHaveLength, 1)
+				So(msg.Data.DataSets[0].Observations, ShouldHaveLength, 21)
+				So(msg.Data.DataSets[0].Observations["0:3"], ShouldResemble, []float64{1})
+				So(msg.Data.DataSets[0].Observations["1:3"], ShouldResemble, []float64{0})
+			})
+		})
+
+		Convey("Then streaming with an invalid format or structure fails without performing the query", func() {
+			_, err := cantabularClient.StaticDatasetQueryStreamSDMX(testCtx, req, "xml", structure, consume)
+			So(err.Error(), ShouldEqual, `invalid sdmx format: "xml"`)
+
+			_, err = cantabularClient.StaticDatasetQueryStreamSDMX(testCtx, req, cantabular.SDMXCSV, cantabular.SDMXStructure{ID: "EXAMPLE"}, consume)
+			So(err.Error(), ShouldEqual, "sdmx structure agency and id must be provided")
+
+			structure.Type = "codelist"
+			_, err = cantabularClient.StaticDatasetQueryStreamSDMX(testCtx, req, cantabular.SDMXJSON, structure, consume)
+			So(err.Error(), ShouldEqual, `invalid sdmx structure type: "codelist"`)
+
+			So(mockHttpClient.PostCalls(), ShouldHaveLength, 0)
+		})
+	})
+
+	Convey("Given a query response converted to SDMX-JSON on a writer that fails", t, func() {
+		structure := cantabular.SDMXStructure{AgencyID: "ONS", ID: "EXAMPLE", Version: "2.1"}
+		_, err := cantabular.GraphQLJSONToSDMXJSON(testCtx, strings.NewReader(mockRespBodyStaticDataset), failingWriter{}, structure)
+
+		Convey("Then the encoding error is returned when the dimensions are written", func() {
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "error writing dimensions: error writing json: write failed")
+		})
+	})
+}
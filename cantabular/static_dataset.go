@@ -17,6 +17,9 @@ import (
 // Consumer is a stream func to read from a reader
 type Consumer = stream.Consumer
 
+// Transformer is a stream func to read from a reader and write the transformed output to a writer
+type Transformer = stream.Transformer
+
 // StaticDataset represents the 'dataset' field from a GraphQL static dataset
 // query response
 type StaticDataset struct {
@@ -129,6 +132,20 @@ func (c *Client) StaticDatasetQuery(ctx context.Context, req StaticDatasetQueryR
 // The number of CSV rows, including the header, is returned along with any error during the process.
 // Use this method if large query responses are expected.
 func (c *Client) StaticDatasetQueryStreamCSV(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer) (int32, error) {
+	return c.StaticDatasetQueryStreamCSVPipeline(ctx, req, consume)
+}
+
+// StaticDatasetQueryStreamCSVPipeline performs a StaticDatasetQuery call and streams the CSV output to the provided Consumer,
+// like StaticDatasetQueryStreamCSV, chaining any provided stages after the CSV transform into a stream.Pipeline,
+// e.g. to compress the CSV output with stream.Gzip before it is consumed.
+// The number of CSV rows, including the header, is returned along with any error during the process.
+func (c *Client) StaticDatasetQueryStreamCSVPipeline(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer, stages ...Transformer) (int32, error) {
+	return c.streamStaticDataset(ctx, req, GraphQLJSONToCSV, consume, stages...)
+}
+
+// streamStaticDataset performs a StaticDatasetQuery call and streams the response body to the provided Consumer,
+// transformed by toFormat followed by any provided stages, returning the count returned by toFormat along with any error during the process.
+func (c *Client) streamStaticDataset(ctx context.Context, req StaticDatasetQueryRequest, toFormat func(ctx context.Context, r io.Reader, w io.Writer) (int32, error), consume Consumer, stages ...Transformer) (int32, error) {
 	data := QueryData{
 		Dataset:   req.Dataset,
 		Variables: req.Variables,
@@ -140,18 +157,88 @@ func (c *Client) StaticDatasetQueryStreamCSV(ctx context.Context, req StaticData
 		closeResponseBody(ctx, res) // close response body, as it is not passed to the Stream func
 		return 0, err
 	}
-	var rowCount int32
 
-	// transform will be executed by Stream when processing the data into 'csv' format.
-	transform := func(ctx context.Context, body io.Reader, pipeWriter io.Writer) error {
-		if rowCount, err = GraphQLJSONToCSV(ctx, body, pipeWriter); err != nil {
-			return err
-		}
-		return nil
+	var count int32
+	transform := func(ctx context.Context, body io.Reader, pipeWriter io.Writer) (err error) {
+		count, err = toFormat(ctx, body, pipeWriter)
+		return err
 	}
 
 	// Stream is responsible for closing the response body
-	return rowCount, stream.Stream(ctx, res.Body, transform, consume)
+	err = stream.Stream(ctx, res.Body, stream.Pipeline(append([]Transformer{transform}, stages...)...), consume)
+	return count, err
+}
+
+// StaticDatasetQueryStreamCSVWithMetadata performs a StaticDatasetQuery call and streams the CSV output to the provided Consumer,
+// like StaticDatasetQueryStreamCSV, also returning the CSVW metadata that describes the CSV, which is published at the provided url.
+// The metadata details are obtained from the provided metadata, which can be obtained with MetadataTableQuery and MetadataDatasetQuery.
+// The returned CSVWMetadata is usually published alongside the CSV file with the same name followed by '-metadata.json'.
+// The number of CSV rows, including the header, is returned along with any error during the process.
+// Use this method if large query responses are expected.
+func (c *Client) StaticDatasetQueryStreamCSVWithMetadata(ctx context.Context, req StaticDatasetQueryRequest, url string, meta *MetadataQueryResult, consume Consumer, stages ...Transformer) (int32, *CSVWMetadata, error) {
+	var csvw *CSVWMetadata
+	toCSV := func(ctx context.Context, r io.Reader, w io.Writer) (rowCount int32, err error) {
+		rowCount, csvw, err = GraphQLJSONToCSVWithMetadata(ctx, r, w, url, meta)
+		return rowCount, err
+	}
+	rowCount, err := c.streamStaticDataset(ctx, req, toCSV, consume, stages...)
+	return rowCount, csvw, err
+}
+
+// StaticDatasetQueryStreamXLSX performs a StaticDatasetQuery call
+// and then starts 2 go-routines to transform the response body into an XLSX stream and
+// consume the transformed output with the provided Consumer concurrently.
+// The workbook contains the observations in a 'Data' sheet, the provided metadata, which can be obtained with
+// MetadataTableQuery and MetadataDatasetQuery, in a 'Metadata' sheet and the table rules in a 'Rules' sheet.
+// Any provided stages are chained after the XLSX transform into a stream.Pipeline.
+// The number of rows in the 'Data' sheet, including the header, is returned along with any error during the process.
+// Use this method if large query responses are expected.
+func (c *Client) StaticDatasetQueryStreamXLSX(ctx context.Context, req StaticDatasetQueryRequest, meta *MetadataQueryResult, consume Consumer, stages ...Transformer) (int32, error) {
+	toXLSX := func(ctx context.Context, r io.Reader, w io.Writer) (int32, error) {
+		return GraphQLJSONToXLSX(ctx, r, w, meta)
+	}
+	return c.streamStaticDataset(ctx, req, toXLSX, consume, stages...)
+}
+
+// StaticDatasetQueryStreamParquet performs a StaticDatasetQuery call
+// and then starts 2 go-routines to transform the response body into a Parquet stream and
+// consume the transformed output with the provided Consumer concurrently.
+// The rows are written in row groups of DefaultParquetRowGroupSize rows.
+// Any provided stages are chained after the Parquet transform into a stream.Pipeline.
+// The number of observations is returned along with any error during the process.
+// Use this method if large query responses are expected.
+func (c *Client) StaticDatasetQueryStreamParquet(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer, stages ...Transformer) (int32, error) {
+	toParquet := func(ctx context.Context, r io.Reader, w io.Writer) (int32, error) {
+		return GraphQLJSONToParquet(ctx, r, w, DefaultParquetRowGroupSize)
+	}
+	return c.streamStaticDataset(ctx, req, toParquet, consume, stages...)
+}
+
+// StaticDatasetQueryStreamSDMX performs a StaticDatasetQuery call
+// and then starts 2 go-routines to transform the response body into an SDMX-CSV or SDMX-JSON data message
+// that references the provided structure, according to the provided format, and consume the transformed output
+// with the provided Consumer concurrently.
+// Any provided stages are chained after the SDMX transform into a stream.Pipeline.
+// The number of observations, which is the same for both formats, is returned along with any error during the process.
+// Use this method if large query responses are expected.
+func (c *Client) StaticDatasetQueryStreamSDMX(ctx context.Context, req StaticDatasetQueryRequest, format SDMXFormat, structure SDMXStructure, consume Consumer, stages ...Transformer) (int32, error) {
+	var toSDMXFormat func(ctx context.Context, r io.Reader, w io.Writer, structure SDMXStructure) (int32, error)
+	switch format {
+	case SDMXCSV:
+		toSDMXFormat = GraphQLJSONToSDMXCSV
+	case SDMXJSON:
+		toSDMXFormat = GraphQLJSONToSDMXJSON
+	default:
+		return 0, fmt.Errorf("invalid sdmx format: %q", format)
+	}
+	if err := structure.Validate(); err != nil {
+		return 0, err
+	}
+
+	toSDMX := func(ctx context.Context, r io.Reader, w io.Writer) (int32, error) {
+		return toSDMXFormat(ctx, r, w, structure)
+	}
+	return c.streamStaticDataset(ctx, req, toSDMX, consume, stages...)
 }
 
 // Checks the number of observations returned from a cantabular query
@@ -211,8 +298,23 @@ func (c *Client) CheckQueryCount(ctx context.Context, req StaticDatasetQueryRequ
 // and then starts 2 go-routines to transform the response body into a Json stream and
 // consume the transformed output with the provided Consumer concurrently.
 // Returns a json formatted response
-// Use this method if large query responses are expected.
+// Use StaticDatasetQueryStreamJsonObservations if large query responses are expected.
 func (c *Client) StaticDatasetQueryStreamJson(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer) (GetObservationsResponse, error) {
+	return c.staticDatasetQueryStreamJson(ctx, req, GraphQLJSONToJson, consume)
+}
+
+// StaticDatasetQueryStreamJsonObservations performs a StaticDatasetQuery call
+// and then starts 2 go-routines to transform the response body into a Json stream and
+// consume the transformed output with the provided Consumer concurrently.
+// The consumer receives a GetObservationsResponse json object, with the observations written as they are decoded.
+// The returned GetObservationsResponse contains the totals of the response, but not its observations.
+// Use this method if large query responses are expected.
+func (c *Client) StaticDatasetQueryStreamJsonObservations(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer) (GetObservationsResponse, error) {
+	return c.staticDatasetQueryStreamJson(ctx, req, GraphQLJSONToJsonStream, consume)
+}
+
+// staticDatasetQueryStreamJson performs a StaticDatasetQuery call and streams the response body transformed by toJson to the Consumer
+func (c *Client) staticDatasetQueryStreamJson(ctx context.Context, req StaticDatasetQueryRequest, toJson func(ctx context.Context, r io.Reader, w io.Writer) (GetObservationsResponse, error), consume Consumer) (GetObservationsResponse, error) {
 	data := QueryData{
 		Dataset:   req.Dataset,
 		Variables: req.Variables,
@@ -231,13 +333,25 @@ func (c *Client) StaticDatasetQueryStreamJson(ctx context.Context, req StaticDat
 
 	// transform will be executed by Stream when processing the data into 'json' format.
 	transform := func(ctx context.Context, body io.Reader, pipeWriter io.Writer) error {
-		if getObservationsResponse, err = GraphQLJSONToJson(ctx, body, pipeWriter); err != nil {
-			return err
+		var errJSON error
+		if getObservationsResponse, errJSON = toJson(ctx, body, pipeWriter); errJSON != nil {
+			return errJSON
 		}
 		return nil
 	}
 
 	// Stream is responsible for closing the response body
-	return getObservationsResponse, stream.Stream(ctx, res.Body, transform, consume)
+	err = stream.Stream(ctx, res.Body, transform, consume)
+	return getObservationsResponse, err
+}
 
+// StaticDatasetQueryStreamJSONStat performs a StaticDatasetQuery call
+// and then starts 2 go-routines to transform the response body into a JSON-stat 2.0 stream and
+// consume the transformed output with the provided Consumer concurrently.
+// The consumer receives a JSON-stat dataset, with the values written as they are decoded.
+// Any provided stages are chained after the JSON-stat transform into a stream.Pipeline.
+// The number of observations is returned along with any error during the process.
+// Use this method if large query responses are expected.
+func (c *Client) StaticDatasetQueryStreamJSONStat(ctx context.Context, req StaticDatasetQueryRequest, consume Consumer, stages ...Transformer) (int32, error) {
+	return c.streamStaticDataset(ctx, req, GraphQLJSONToJSONStat, consume, stages...)
 }
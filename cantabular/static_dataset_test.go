@@ -2,7 +2,9 @@ package cantabular_test
 
 import (
 	"bufio"
+	"compress/gzip"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -15,6 +17,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular/gql"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	"github.com/ONSdigital/log.go/v2/log"
 )
@@ -64,6 +67,27 @@ func TestStream(t *testing.T) {
 				So(rowCount, ShouldEqual, 22)
 			})
 
+			Convey("Then the expected CSV is successfully streamed through the provided pipeline stages", func() {
+				req := cantabular.StaticDatasetQueryRequest{
+					Dataset:   "Example",
+					Variables: []string{"city", "siblings"},
+					Filters:   []cantabular.Filter{{Variable: "city", Codes: []string{"0", "1"}}},
+				}
+				var lineCount int64
+				gzipConsume := func(ctx context.Context, r io.Reader) error {
+					gr, err := gzip.NewReader(r)
+					if err != nil {
+						return err
+					}
+					return consume(ctx, gr)
+				}
+				rowCount, err := cantabularClient.StaticDatasetQueryStreamCSVPipeline(testCtx, req, gzipConsume, stream.CountLines(&lineCount), stream.Gzip())
+				So(err, ShouldBeNil)
+				So(out, ShouldResemble, expectedCsv)
+				So(rowCount, ShouldEqual, 22)
+				So(lineCount, ShouldEqual, 22)
+			})
+
 			Convey("Then calling stream with a cancelled context results in the expected error being returned and only the first line being processed", func() {
 				testCtxWithCancel, cancel := context.WithCancel(testCtx)
 				cancel()
@@ -213,6 +237,163 @@ func TestStream(t *testing.T) {
 	})
 }
 
+func TestStreamJson(t *testing.T) {
+	Convey("Given a stream consumer that reads the whole json output", t, func() {
+		var out []byte
+		consume := func(ctx context.Context, r io.Reader) error {
+			var err error
+			out, err = io.ReadAll(r)
+			return err
+		}
+
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				return Response([]byte(mockRespBodyStaticDataset), http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		req := cantabular.StaticDatasetQueryRequest{
+			Dataset:   "Example",
+			Variables: []string{"city", "siblings"},
+		}
+
+		Convey("When the static dataset query is streamed as json", func() {
+			resp, err := cantabularClient.StaticDatasetQueryStreamJson(testCtx, req, consume)
+			So(err, ShouldBeNil)
+
+			Convey("Then the consumer receives each observation as a separate json object", func() {
+				lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+				So(lines, ShouldHaveLength, 21)
+				So(lines[0], ShouldStartWith, `{"dimensions":[`)
+			})
+
+			Convey("Then the returned response contains all the observations", func() {
+				So(resp.Observations, ShouldHaveLength, 21)
+			})
+		})
+
+		Convey("When the static dataset query observations are streamed as json", func() {
+			resp, err := cantabularClient.StaticDatasetQueryStreamJsonObservations(testCtx, req, consume)
+			So(err, ShouldBeNil)
+
+			Convey("Then the consumer receives a valid observations response with all the observations and totals", func() {
+				var streamed cantabular.GetObservationsResponse
+				So(json.Unmarshal(out, &streamed), ShouldBeNil)
+				So(streamed.Observations, ShouldHaveLength, 21)
+				So(streamed.Observations[3], ShouldResemble, cantabular.GetObservationResponse{
+					Dimensions: []cantabular.ObservationDimension{
+						{Dimension: "City", DimensionID: "city", Option: "London", OptionID: "0"},
+						{Dimension: "Number of siblings", DimensionID: "siblings", Option: "3 siblings", OptionID: "3"},
+					},
+					Observation: 1,
+				})
+				So(streamed.TotalObservations, ShouldEqual, 21)
+			})
+
+			Convey("Then the returned response contains the totals without holding the observations", func() {
+				So(resp.Observations, ShouldBeNil)
+				So(resp.TotalObservations, ShouldEqual, 21)
+			})
+		})
+	})
+}
+
+func TestGraphQLJSONToJson(t *testing.T) {
+	Convey("Given a static dataset query response", t, func() {
+		Convey("When it is converted to json", func() {
+			out := &strings.Builder{}
+			resp, err := cantabular.GraphQLJSONToJson(testCtx, strings.NewReader(mockRespBodyStaticDataset), out)
+			So(err, ShouldBeNil)
+
+			Convey("Then each observation is written as a separate json object", func() {
+				dec := json.NewDecoder(strings.NewReader(out.String()))
+				var observations []cantabular.GetObservationResponse
+				for dec.More() {
+					var obs cantabular.GetObservationResponse
+					So(dec.Decode(&obs), ShouldBeNil)
+					observations = append(observations, obs)
+				}
+				So(observations, ShouldResemble, resp.Observations)
+			})
+
+			Convey("Then the returned response contains the observations", func() {
+				So(resp.Observations, ShouldHaveLength, 21)
+				So(resp.Observations[3].Observation, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When it is converted to json on a writer that fails", func() {
+			_, err := cantabular.GraphQLJSONToJson(testCtx, strings.NewReader(mockRespBodyStaticDataset), failingWriter{})
+
+			Convey("Then the encoding error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldContainSubstring, "error encoding observation: write failed")
+			})
+		})
+	})
+}
+
+func TestGraphQLJSONToJsonStream(t *testing.T) {
+	Convey("Given a static dataset query response", t, func() {
+		Convey("When it is converted to a json stream", func() {
+			out := &strings.Builder{}
+			resp, err := cantabular.GraphQLJSONToJsonStream(testCtx, strings.NewReader(mockRespBodyStaticDataset), out)
+			So(err, ShouldBeNil)
+
+			Convey("Then the observations are written as a single observations response document without empty links", func() {
+				So(out.String(), ShouldStartWith, `{"observations":[{"dimensions":[`)
+				So(out.String(), ShouldEndWith, `}],"total_observations":21,"blocked_areas":0,"total_areas":0,"areas_returned":0}`)
+				So(out.String(), ShouldNotContainSubstring, `"links"`)
+			})
+
+			Convey("Then the returned response does not carry the observations", func() {
+				So(resp.Observations, ShouldBeNil)
+				So(resp.TotalObservations, ShouldEqual, 21)
+			})
+		})
+
+		Convey("When it is converted to a json stream on a writer that fails", func() {
+			_, err := cantabular.GraphQLJSONToJsonStream(testCtx, strings.NewReader(mockRespBodyStaticDataset), failingWriter{})
+
+			Convey("Then the encoding error is returned", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "error encoding start of observations response: error writing json: write failed")
+			})
+		})
+	})
+}
+
+func TestGraphQLJSONToCSV(t *testing.T) {
+	Convey("Given a truncated query response", t, func() {
+		r := strings.NewReader(mockRespBodyStaticDataset[:len(mockRespBodyStaticDataset)-20])
+
+		Convey("When it is converted to csv on a writer that fails", func() {
+			_, err := cantabular.GraphQLJSONToCSV(testCtx, r, failingWriter{})
+
+			Convey("Then the decoding error is returned instead of the csv writer error", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldNotContainSubstring, "csv writer error")
+			})
+		})
+	})
+}
+
+// failingWriter is an io.Writer that always fails
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
 func TestStaticDatasetQueryHappy(t *testing.T) {
 	Convey("Given a correct response from the /graphql endpoint", t, func() {
 		testCtx := context.Background()
@@ -75,9 +75,50 @@ var (
 	errNullTable   = errors.New(`table object expected but "null" found`)
 )
 
+// rowWriter writes the rows of a table as they are decoded from a GraphQL response, starting with the header
+type rowWriter interface {
+	Write(row []string) error
+}
+
+// dimensionsWriter is implemented by the row writers that also write the dimensions of the table,
+// which are provided before any row is written
+type dimensionsWriter interface {
+	WriteDimensions(dims Dimensions) error
+}
+
+// rulesWriter is implemented by the row writers that also write the disclosure rules of the table,
+// which are provided once all the table fields are decoded
+type rulesWriter interface {
+	WriteRules(rules Rules) error
+}
+
 // GraphQLJSONToCSV converts a JSON response in r to CSV on w, returning the row count
 // if an error happens, the process is aborted and the error is returned.
-func GraphQLJSONToCSV(ctx context.Context, r io.Reader, w io.Writer) (int32, error) {
+func GraphQLJSONToCSV(ctx context.Context, r io.Reader, w io.Writer) (rowCount int32, err error) {
+	cw := csv.NewWriter(w)
+	// csv.Writer errors are sticky, so we only need to check when flushing at the end
+	defer func() {
+		cw.Flush()
+		if cwErr := cw.Error(); cwErr != nil && err == nil {
+			err = fmt.Errorf("csv writer error: %w", cwErr)
+		}
+	}()
+
+	return graphQLJSONToRows(ctx, r, cw)
+}
+
+// observationCount returns the number of observations of a table with the provided row count, which includes the header.
+// Formats that do not write a header, like SDMX-JSON and JSON-stat, report this count instead of the row count.
+func observationCount(rowCount int32) int32 {
+	if rowCount == 0 {
+		return 0
+	}
+	return rowCount - 1
+}
+
+// graphQLJSONToRows converts a JSON response in r to rows written to rw, returning the row count
+// if an error happens, the process is aborted and the error is returned.
+func graphQLJSONToRows(ctx context.Context, r io.Reader, rw rowWriter) (int32, error) {
 	dec := jsonstream.New(r)
 
 	// errData represents a possible error that may be returned by 'decodeDataFields',
@@ -102,7 +143,7 @@ func GraphQLJSONToCSV(ctx context.Context, r io.Reader, w io.Writer) (int32, err
 		}
 		switch field {
 		case "data":
-			if rowCount, err = decodeDataFields(ctx, dec, w); err != nil {
+			if rowCount, err = decodeDataFields(ctx, dec, rw); err != nil {
 				// null values for 'dataste' or 'table' are ok as long as the error is reported under the 'errors' field
 				if err == errNullDataset || err == errNullTable {
 					errData = err
@@ -129,9 +170,84 @@ func GraphQLJSONToCSV(ctx context.Context, r io.Reader, w io.Writer) (int32, err
 	return rowCount, nil
 }
 
-// GraphQLJSONToJson converts a JSON response in r to a different schema on w, returning the response
+// GraphQLJSONToJson converts a JSON response in r to a different schema on w, returning the response.
+// Each observation is written to w as a separate JSON object, and is also held in the Observations of the returned response.
+// Use GraphQLJSONToJsonStream to avoid holding all the observations in memory.
 // if an error happens, the process is aborted and the error is returned.
 func GraphQLJSONToJson(ctx context.Context, r io.Reader, w io.Writer) (GetObservationsResponse, error) {
+	enc := json.NewEncoder(w)
+
+	var observations []GetObservationResponse
+	getObservationsResponse, err := graphQLJSONToObservations(ctx, r, func(obs GetObservationResponse) error {
+		observations = append(observations, obs)
+		return enc.Encode(obs)
+	})
+	if err != nil {
+		return GetObservationsResponse{}, err
+	}
+
+	getObservationsResponse.Observations = observations
+	return getObservationsResponse, nil
+}
+
+// GraphQLJSONToJsonStream converts a JSON response in r to a GetObservationsResponse JSON object on w.
+// Each observation is written to w as soon as it is decoded, followed by the totals once all the observations are written.
+// The returned response contains the totals, but not the observations, so that they are not held in memory.
+// if an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToJsonStream(ctx context.Context, r io.Reader, w io.Writer) (GetObservationsResponse, error) {
+	enc := jsonstream.NewEncoder(w)
+
+	// start the response object with the 'observations' array, which are written as they are decoded
+	if err := enc.StartObject(); err != nil {
+		return GetObservationsResponse{}, fmt.Errorf("error encoding start of observations response: %w", err)
+	}
+	if err := enc.StartArrayField("observations"); err != nil {
+		return GetObservationsResponse{}, fmt.Errorf("error encoding start of observations: %w", err)
+	}
+
+	getObservationsResponse, err := graphQLJSONToObservations(ctx, r, func(obs GetObservationResponse) error {
+		return enc.Encode(obs)
+	})
+	if err != nil {
+		return GetObservationsResponse{}, err
+	}
+
+	// end the 'observations' array and write the rest of the response fields
+	if err := enc.EndArray(); err != nil {
+		return GetObservationsResponse{}, fmt.Errorf("error encoding end of observations: %w", err)
+	}
+	if getObservationsResponse.Links != (DatasetJSONLinks{}) {
+		if err := enc.Field("links", getObservationsResponse.Links); err != nil {
+			return GetObservationsResponse{}, fmt.Errorf("error encoding 'links' field: %w", err)
+		}
+	}
+	fields := []struct {
+		name  string
+		value interface{}
+	}{
+		{"total_observations", getObservationsResponse.TotalObservations},
+		{"blocked_areas", getObservationsResponse.BlockedAreas},
+		{"total_areas", getObservationsResponse.TotalAreas},
+		{"areas_returned", getObservationsResponse.AreasReturned},
+	}
+	for _, f := range fields {
+		if err := enc.Field(f.name, f.value); err != nil {
+			return GetObservationsResponse{}, fmt.Errorf("error encoding '%s' field: %w", f.name, err)
+		}
+	}
+	if err := enc.EndObject(); err != nil {
+		return GetObservationsResponse{}, fmt.Errorf("error encoding end of observations response: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return GetObservationsResponse{}, fmt.Errorf("error encoding observations response: %w", err)
+	}
+
+	return getObservationsResponse, nil
+}
+
+// graphQLJSONToObservations decodes a JSON response in r, calling writeObservation with each observation as soon as it is decoded.
+// It returns the response totals, without the observations.
+func graphQLJSONToObservations(ctx context.Context, r io.Reader, writeObservation func(GetObservationResponse) error) (GetObservationsResponse, error) {
 	dec := jsonstream.New(r)
 
 	// errData represents a possible error that may be returned by 'decodeDataFields',
@@ -155,7 +271,7 @@ func GraphQLJSONToJson(ctx context.Context, r io.Reader, w io.Writer) (GetObserv
 		}
 		switch field {
 		case "data":
-			if getObservationsResponse, err = decodeDataFieldsJson(ctx, dec, w); err != nil {
+			if getObservationsResponse, err = decodeDataFieldsJson(ctx, dec, writeObservation); err != nil {
 				// null values for 'dataset' or 'table' are ok as long as the error is reported under the 'errors' field
 				if err == errNullDataset || err == errNullTable {
 					errData = err
@@ -182,10 +298,12 @@ func GraphQLJSONToJson(ctx context.Context, r io.Reader, w io.Writer) (GetObserv
 	return getObservationsResponse, nil
 }
 
-// decodeTableFields decodes the fields of the table part of the GraphQL response, writing CSV to w.
+// decodeTableFields decodes the fields of the table part of the GraphQL response, writing rows to rw.
 // It returns the total number of rows, including the header.
-// If no table cell values are present then no output is written.
-func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer) (rowCount int32, err error) {
+// If no table cell values are present then no rows are written.
+// If rw is a dimensionsWriter, the dimensions of the table are written before the values,
+// and if it is a rulesWriter, the rules of the table are written once all the fields are decoded.
+func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, rw rowWriter) (rowCount int32, err error) {
 	var dims Dimensions
 	var rules Rules
 	for dec.More() {
@@ -219,7 +337,12 @@ func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer)
 				return 0, fmt.Errorf("error decoding start of json array for 'values': %w", err)
 			}
 			if isStartArray {
-				if rowCount, err = decodeValues(ctx, dec, dims, w); err != nil {
+				if w, ok := rw.(dimensionsWriter); ok {
+					if err := w.WriteDimensions(dims); err != nil {
+						return 0, fmt.Errorf("error writing dimensions: %w", err)
+					}
+				}
+				if rowCount, err = decodeValues(ctx, dec, dims, rw); err != nil {
 					return 0, fmt.Errorf("error decoding values: %w", err)
 				}
 				if err := dec.EndComposite(); err != nil {
@@ -228,10 +351,17 @@ func decodeTableFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer)
 			}
 		}
 	}
+	if w, ok := rw.(rulesWriter); ok {
+		if err := w.WriteRules(rules); err != nil {
+			return 0, fmt.Errorf("error writing rules: %w", err)
+		}
+	}
 	return rowCount, nil
 }
 
-func decodeTableFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Writer) (getObservationsResponse GetObservationsResponse, err error) {
+// decodeTableFieldsJson decodes the fields of the table part of the GraphQL response, calling writeObservation with each observation.
+// It returns the response totals, without the observations.
+func decodeTableFieldsJson(ctx context.Context, dec jsonstream.Decoder, writeObservation func(GetObservationResponse) error) (getObservationsResponse GetObservationsResponse, err error) {
 	var dims Dimensions
 	var rules Rules
 	var getObsResponse GetObservationsResponse
@@ -273,7 +403,7 @@ func decodeTableFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Wri
 				return GetObservationsResponse{}, fmt.Errorf("error decoding start of json array for 'values': %w", err)
 			}
 			if isStartArray {
-				if getObsResponse, err = decodeValuesJson(ctx, dec, dims, w); err != nil {
+				if getObsResponse.TotalObservations, err = decodeValuesJson(ctx, dec, dims, writeObservation); err != nil {
 					return GetObservationsResponse{}, fmt.Errorf("error decoding values: %w", err)
 				}
 				if err := dec.EndComposite(); err != nil {
@@ -312,7 +442,7 @@ func decodeErrors(dec jsonstream.Decoder) error {
 // if expects to find the following nested values: {"dataset": {"table": {...}}}
 // it propagates the row count returned by 'decodeTableFields'
 // the end-composite values are always decoded according to the reached depth
-func decodeDataFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer) (rowCount int32, err error) {
+func decodeDataFields(ctx context.Context, dec jsonstream.Decoder, rw rowWriter) (rowCount int32, err error) {
 	var matchName = func(name string) error {
 		gotName, err := dec.DecodeName()
 		if err != nil {
@@ -370,13 +500,13 @@ func decodeDataFields(ctx context.Context, dec jsonstream.Decoder, w io.Writer)
 	depth++
 
 	// Decode table fields
-	if rowCount, err = decodeTableFields(ctx, dec, w); err != nil {
+	if rowCount, err = decodeTableFields(ctx, dec, rw); err != nil {
 		return 0, fmt.Errorf("error decoding table fields: %w", err)
 	}
 	return rowCount, nil
 }
 
-func decodeDataFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Writer) (getObservationsResponse GetObservationsResponse, err error) {
+func decodeDataFieldsJson(ctx context.Context, dec jsonstream.Decoder, writeObservation func(GetObservationResponse) error) (getObservationsResponse GetObservationsResponse, err error) {
 	var matchName = func(name string) error {
 		gotName, err := dec.DecodeName()
 		if err != nil {
@@ -434,27 +564,18 @@ func decodeDataFieldsJson(ctx context.Context, dec jsonstream.Decoder, w io.Writ
 	depth++
 
 	// Decode table fields
-	if getObservationsResponse, err = decodeTableFieldsJson(ctx, dec, w); err != nil {
+	if getObservationsResponse, err = decodeTableFieldsJson(ctx, dec, writeObservation); err != nil {
 		return GetObservationsResponse{}, fmt.Errorf("error decoding table fields: %w", err)
 	}
 	return getObservationsResponse, nil
 }
 
-// decodeValues decodes the values of the cells in the table, writing CSV to w.
+// decodeValues decodes the values of the cells in the table, writing rows to rw.
 // It returns the total number of rows, including the header.
-func decodeValues(ctx context.Context, dec jsonstream.Decoder, dims Dimensions, w io.Writer) (rowCount int32, err error) {
-	cw := csv.NewWriter(w)
-	// csv.Writer errors are sticky, so we only need to check when flushing at the end
-	defer func() {
-		cw.Flush()
-		if cwErr := cw.Error(); cwErr != nil {
-			err = fmt.Errorf("csv writer error: %w", cwErr)
-		}
-	}()
-
+func decodeValues(ctx context.Context, dec jsonstream.Decoder, dims Dimensions, rw rowWriter) (rowCount int32, err error) {
 	// Create and write header separately
 	header := createCSVHeader(dims)
-	if err = cw.Write(header); err != nil {
+	if err = rw.Write(header); err != nil {
 		return 0, fmt.Errorf("error writing the csv header: %w", err)
 	}
 	rowCount = 1 // number of rows, including headers
@@ -469,7 +590,7 @@ func decodeValues(ctx context.Context, dec jsonstream.Decoder, dims Dimensions,
 		if err != nil {
 			return 0, fmt.Errorf("error parsing a csv row: %w", err)
 		}
-		if err = cw.Write(row); err != nil {
+		if err = rw.Write(row); err != nil {
 			return 0, fmt.Errorf("error writing a csv row: %w", err)
 		}
 		rowCount++
@@ -481,30 +602,32 @@ func decodeValues(ctx context.Context, dec jsonstream.Decoder, dims Dimensions,
 	return rowCount, nil
 }
 
-func decodeValuesJson(ctx context.Context, dec jsonstream.Decoder, dims Dimensions, w io.Writer) (getObsResponse GetObservationsResponse, err error) {
-
-	obsResp := json.NewEncoder(w)
-	var getObservationsResponse GetObservationsResponse
+// decodeValuesJson decodes the values of the cells in the table, calling writeObservation with each of them as an observation.
+// It returns the number of observations.
+func decodeValuesJson(ctx context.Context, dec jsonstream.Decoder, dims Dimensions, writeObservation func(GetObservationResponse) error) (int, error) {
+	count := 0
 
 	// Obtain the Json objects according to the cantabular dimensions and counts
 	for ti := dims.NewIterator(ctx); dec.More(); {
-		count, err := dec.DecodeNumber()
+		value, err := dec.DecodeNumber()
 		if err != nil {
-			return GetObservationsResponse{}, fmt.Errorf("error decoding count: %w", err)
+			return 0, fmt.Errorf("error decoding count: %w", err)
 		}
-		row, err := ti.createJsonObject(dims, count.String())
+		row, err := ti.createJsonObject(dims, value.String())
 		if err != nil {
-			return GetObservationsResponse{}, fmt.Errorf("error parsing a json node: %w", err)
+			return 0, fmt.Errorf("error parsing a json node: %w", err)
+		}
+		if err := writeObservation(row); err != nil {
+			return 0, fmt.Errorf("error encoding observation: %w", err)
 		}
-		obsResp.Encode(row)
-		getObservationsResponse.Observations = append(getObservationsResponse.Observations, row)
+		count++
 
 		if err := ti.Next(); err != nil {
-			return GetObservationsResponse{}, fmt.Errorf("error iterating to next json node: %w", err)
+			return 0, fmt.Errorf("error iterating to next json node: %w", err)
 		}
 	}
 
-	return getObservationsResponse, nil
+	return count, nil
 }
 
 // createCSVHeader creates an array of strings corresponding to a csv header
@@ -0,0 +1,312 @@
+package cantabular
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/shurcooL/graphql"
+)
+
+// xlsxMaxRows is the maximum number of rows that a spreadsheet can have
+const xlsxMaxRows = 1048576
+
+// xlsxSheets are the names of the sheets of the XLSX workbook, in the order they are written.
+// The worksheet at index i is stored as 'xl/worksheets/sheet{i+1}.xml'
+var xlsxSheets = []string{"Data", "Metadata", "Rules"}
+
+const (
+	xlsxHeader          = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n"
+	xlsxSheetStart      = xlsxHeader + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+	xlsxSheetEnd        = `</sheetData></worksheet>`
+	xlsxRelationships   = `http://schemas.openxmlformats.org/package/2006/relationships`
+	xlsxDocRelationship = `http://schemas.openxmlformats.org/officeDocument/2006/relationships`
+)
+
+// GraphQLJSONToXLSX converts a JSON response in r to an XLSX workbook on w, returning the row count of the data sheet, including the header.
+// The workbook has a 'Data' sheet with the same columns as the CSV generated by GraphQLJSONToCSV, a 'Metadata' sheet with the fields of the
+// provided metadata, which may be nil, and a 'Rules' sheet with the disclosure control rules of the table.
+// The rows are written to w as they are decoded, so the table is never held in memory.
+// If an error happens, the process is aborted and the error is returned.
+func GraphQLJSONToXLSX(ctx context.Context, r io.Reader, w io.Writer, meta *MetadataQueryResult) (int32, error) {
+	xw, err := newXLSXWriter(w, meta)
+	if err != nil {
+		return 0, fmt.Errorf("xlsx writer error: %w", err)
+	}
+
+	rowCount, err := graphQLJSONToRows(ctx, r, xw)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := xw.Close(); err != nil {
+		return 0, fmt.Errorf("xlsx writer error: %w", err)
+	}
+	return rowCount, nil
+}
+
+// xlsxWriter writes an XLSX workbook as a zip stream. The rows written with Write are streamed to the data sheet,
+// and the metadata and rules sheets are written when the writer is closed.
+type xlsxWriter struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	rows  int
+	meta  *MetadataQueryResult
+	rules Rules
+}
+
+// newXLSXWriter creates an xlsxWriter that writes to w, writing the workbook parts and the start of the data sheet
+func newXLSXWriter(w io.Writer, meta *MetadataQueryResult) (*xlsxWriter, error) {
+	xw := &xlsxWriter{
+		zw:   zip.NewWriter(w),
+		meta: meta,
+	}
+
+	var contentTypes, rels, sheets strings.Builder
+	for i, name := range xlsxSheets {
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="%s/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, xlsxDocRelationship, i+1)
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, name, i+1, i+1)
+	}
+
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+			`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+			`<Default Extension="xml" ContentType="application/xml"/>` +
+			`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+			contentTypes.String() + `</Types>`},
+		{"_rels/.rels", `<Relationships xmlns="` + xlsxRelationships + `">` +
+			`<Relationship Id="rId1" Type="` + xlsxDocRelationship + `/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="` + xlsxDocRelationship + `">` +
+			`<sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<Relationships xmlns="` + xlsxRelationships + `">` + rels.String() + `</Relationships>`},
+	}
+	for _, part := range parts {
+		f, err := xw.zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, xlsxHeader+part.content); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := xw.startSheet(0); err != nil {
+		return nil, err
+	}
+	return xw, nil
+}
+
+// Write writes a row to the data sheet. The first row is the header, and the last column of the following rows is the observation,
+// which is written as a number.
+func (xw *xlsxWriter) Write(row []string) error {
+	numericCol := -1
+	if xw.rows > 0 {
+		numericCol = len(row) - 1
+	}
+	return xw.writeRow(row, numericCol)
+}
+
+// WriteRules keeps the rules of the table, which are written to the rules sheet when the writer is closed
+func (xw *xlsxWriter) WriteRules(rules Rules) error {
+	xw.rules = rules
+	return nil
+}
+
+// Close ends the data sheet, writes the metadata and rules sheets and finishes the zip stream.
+// It does not close the underlying writer.
+func (xw *xlsxWriter) Close() error {
+	if err := xw.endSheet(); err != nil {
+		return err
+	}
+
+	if err := xw.startSheet(1); err != nil {
+		return err
+	}
+	if err := xw.writeRow([]string{"Field", "Value"}, -1); err != nil {
+		return err
+	}
+	for _, row := range metadataRows(xw.meta) {
+		if err := xw.writeRow(row, -1); err != nil {
+			return err
+		}
+	}
+	if err := xw.endSheet(); err != nil {
+		return err
+	}
+
+	if err := xw.startSheet(2); err != nil {
+		return err
+	}
+	if err := xw.writeRules(); err != nil {
+		return err
+	}
+	if err := xw.endSheet(); err != nil {
+		return err
+	}
+
+	return xw.zw.Close()
+}
+
+// writeRules writes the count of each rule, followed by the categories of each rule
+func (xw *xlsxWriter) writeRules() error {
+	rules := []struct {
+		name string
+		rule RuleVariable
+	}{
+		{"Blocked", xw.rules.Blocked},
+		{"Passed", xw.rules.Passed},
+		{"Evaluated", xw.rules.Total},
+	}
+
+	if err := xw.writeRow([]string{"Rule", "Count"}, -1); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		if err := xw.writeRow([]string{r.name, strconv.Itoa(r.rule.Count)}, 1); err != nil {
+			return err
+		}
+	}
+
+	if err := xw.writeRow(nil, -1); err != nil {
+		return err
+	}
+	if err := xw.writeRow([]string{"Rule", "Category Code", "Category"}, -1); err != nil {
+		return err
+	}
+	for _, r := range rules {
+		for _, c := range r.rule.Categories {
+			if err := xw.writeRow([]string{r.name, c.Code, c.Label}, -1); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (xw *xlsxWriter) startSheet(i int) error {
+	f, err := xw.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1))
+	if err != nil {
+		return err
+	}
+	xw.sheet = f
+	xw.rows = 0
+	_, err = io.WriteString(xw.sheet, xlsxSheetStart)
+	return err
+}
+
+func (xw *xlsxWriter) endSheet() error {
+	_, err := io.WriteString(xw.sheet, xlsxSheetEnd)
+	return err
+}
+
+// writeRow writes a row to the current sheet. The cells are written as inline strings,
+// apart from the cell at numericCol, which is written as a number if it is a finite one.
+// Values such as NaN or Inf cannot be represented as numeric cells, so they are written as inline strings.
+func (xw *xlsxWriter) writeRow(row []string, numericCol int) error {
+	if xw.rows == xlsxMaxRows {
+		return fmt.Errorf("xlsx sheet exceeded maximum number of rows: %d", xlsxMaxRows)
+	}
+	xw.rows++
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<row r="%d">`, xw.rows)
+	for i, value := range row {
+		ref := xlsxColumn(i) + strconv.Itoa(xw.rows)
+		if i == numericCol {
+			if f, err := strconv.ParseFloat(value, 64); err == nil && !math.IsNaN(f) && !math.IsInf(f, 0) {
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, strconv.FormatFloat(f, 'g', -1, 64))
+				continue
+			}
+		}
+		fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">`, ref)
+		if err := xml.EscapeText(&b, []byte(value)); err != nil {
+			return err
+		}
+		b.WriteString(`</t></is></c>`)
+	}
+	b.WriteString(`</row>`)
+
+	_, err := io.WriteString(xw.sheet, b.String())
+	return err
+}
+
+// xlsxColumn returns the name of the column at the provided index, e.g. 'A' for 0 and 'AA' for 26
+func xlsxColumn(i int) string {
+	name := ""
+	for i >= 0 {
+		name = string(rune('A'+i%26)) + name
+		i = i/26 - 1
+	}
+	return name
+}
+
+// metadataRows returns the non-empty fields of the provided metadata as rows of field names and values
+func metadataRows(meta *MetadataQueryResult) [][]string {
+	var rows [][]string
+	add := func(field string, value graphql.String) {
+		if value != "" {
+			rows = append(rows, []string{field, string(value)})
+		}
+	}
+	if meta == nil {
+		return rows
+	}
+
+	if meta.TableQueryResult != nil {
+		for _, t := range meta.TableQueryResult.Service.Tables {
+			add("Table", t.Name)
+			add("Table label", t.Label)
+			add("Table description", t.Description)
+			add("Dataset", t.DatasetName)
+			vars := make([]string, len(t.Vars))
+			for i, v := range t.Vars {
+				vars[i] = string(v)
+			}
+			add("Variables", graphql.String(strings.Join(vars, ", ")))
+			add("Dataset population", t.Meta.DatasetPopulation)
+			add("Geographic coverage", t.Meta.GeographicCoverage)
+			add("Statistical unit", t.Meta.StatisticalUnit.StatisticalUnit)
+			add("Observation type", t.Meta.Observation_Type.Observation_Type_Label)
+			add("Last updated", t.Meta.LastUpdated)
+			add("Version", t.Meta.Version)
+			for _, r := range t.Meta.CensusReleases {
+				add("Census release", r.CensusReleaseDescription)
+				add("Release date", r.ReleaseDate)
+			}
+			for _, p := range t.Meta.Publications {
+				add("Publication", p.PublicationTitle)
+				add("Publisher", p.PublisherName)
+				add("Publisher website", p.PublisherWebsite)
+			}
+			add("Contact name", t.Meta.Contact.ContactName)
+			add("Contact email", t.Meta.Contact.ContactEmail)
+			add("Contact phone", t.Meta.Contact.ContactPhone)
+			add("Contact website", t.Meta.Contact.ContactWebsite)
+		}
+	}
+
+	if meta.DatasetQueryResult != nil {
+		ds := meta.DatasetQueryResult.Dataset
+		add("Dataset label", ds.Label)
+		add("Dataset description", ds.Description)
+		add("Licence", ds.Meta.Source.Licence)
+		add("Methodology", ds.Meta.Source.MethodologyLink)
+		add("Methodology statement", ds.Meta.Source.MethodologyStatement)
+		add("National statistic certified", ds.Meta.Source.NationalStatisticCertified)
+		add("Source contact name", ds.Meta.Source.Contact.ContactName)
+		add("Source contact email", ds.Meta.Source.Contact.ContactEmail)
+		add("Source contact phone", ds.Meta.Source.Contact.ContactPhone)
+		add("Source contact website", ds.Meta.Source.Contact.ContactWebsite)
+		for _, v := range ds.Vars {
+			add(fmt.Sprintf("Variable %s", v.Name), v.Label)
+			add(fmt.Sprintf("Variable %s description", v.Name), v.Description)
+		}
+	}
+	return rows
+}
@@ -0,0 +1,159 @@
+package cantabular_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	dphttp "github.com/ONSdigital/dp-net/v2/http"
+)
+
+func TestStreamXLSX(t *testing.T) {
+	Convey("Given a stream consumer that reads the whole xlsx output", t, func() {
+		var out []byte
+		consume := func(ctx context.Context, r io.Reader) error {
+			var err error
+			out, err = io.ReadAll(r)
+			return err
+		}
+
+		mockHttpClient := &dphttp.ClienterMock{
+			PostFunc: func(ctx context.Context, url string, contentType string, body io.Reader) (*http.Response, error) {
+				return Response([]byte(mockRespBodyStaticDatasetRules), http.StatusOK), nil
+			},
+		}
+
+		cantabularClient := cantabular.NewClient(
+			cantabular.Config{
+				Host:       "cantabular.host",
+				ExtApiHost: "cantabular.ext.host",
+			},
+			mockHttpClient,
+			nil,
+		)
+
+		req := cantabular.StaticDatasetQueryRequest{
+			Dataset:   "Example",
+			Variables: []string{"city", "siblings"},
+		}
+
+		Convey("When the static dataset query is streamed as xlsx with metadata", func() {
+			meta := &cantabular.MetadataQueryResult{
+				DatasetQueryResult: &cantabular.MetadataDatasetQuery{},
+			}
+			meta.DatasetQueryResult.Dataset.Label = "Example & Co"
+			meta.DatasetQueryResult.Dataset.Meta.Source.Licence = "Open Government Licence"
+
+			rowCount, err := cantabularClient.StaticDatasetQueryStreamXLSX(testCtx, req, meta, consume)
+
+			Convey("Then the expected number of rows is returned", func() {
+				So(err, ShouldBeNil)
+				So(rowCount, ShouldEqual, 22)
+			})
+
+			Convey("Then the consumer receives a workbook with the data, metadata and rules sheets", func() {
+				f := openXLSX(out)
+				So(f.GetSheetList(), ShouldResemble, []string{"Data", "Metadata", "Rules"})
+
+				data, err := f.GetRows("Data")
+				So(err, ShouldBeNil)
+				So(data, ShouldHaveLength, 22)
+				So(data[0], ShouldResemble, []string{"City Code", "City", "Number of siblings Code", "Number of siblings", "Observation"})
+				So(data[4], ShouldResemble, []string{"0", "London", "3", "3 siblings", "1"})
+				So(cellType(f, "Data", "A1"), ShouldEqual, excelize.CellTypeInlineString)
+				So(cellType(f, "Data", "D5"), ShouldEqual, excelize.CellTypeInlineString)
+				So(cellType(f, "Data", "E5"), ShouldEqual, excelize.CellTypeUnset) // cells without a type are numbers
+
+				metadata, err := f.GetRows("Metadata")
+				So(err, ShouldBeNil)
+				So(metadata, ShouldContain, []string{"Dataset label", "Example & Co"})
+				So(metadata, ShouldContain, []string{"Licence", "Open Government Licence"})
+
+				rules, err := f.GetRows("Rules")
+				So(err, ShouldBeNil)
+				So(rules[1], ShouldResemble, []string{"Blocked", "1"})
+				So(rules[3], ShouldResemble, []string{"Evaluated", "3"})
+				So(rules[6], ShouldResemble, []string{"Blocked", "2", "Belfast"})
+				So(cellType(f, "Rules", "B2"), ShouldEqual, excelize.CellTypeUnset)
+				So(cellType(f, "Rules", "B7"), ShouldEqual, excelize.CellTypeInlineString)
+			})
+		})
+
+		Convey("When the static dataset query is streamed as xlsx without metadata", func() {
+			_, err := cantabularClient.StaticDatasetQueryStreamXLSX(testCtx, req, nil, consume)
+			So(err, ShouldBeNil)
+
+			Convey("Then the metadata sheet only contains the header", func() {
+				metadata, err := openXLSX(out).GetRows("Metadata")
+				So(err, ShouldBeNil)
+				So(metadata, ShouldHaveLength, 1)
+			})
+		})
+	})
+
+	Convey("Given an xlsx writer", t, func() {
+		out := &bytes.Buffer{}
+		w, err := cantabular.NewXLSXWriter(out, nil)
+		So(err, ShouldBeNil)
+
+		Convey("When observations that are not finite numbers are written", func() {
+			So(w.Write([]string{"Code", "Observation"}), ShouldBeNil)
+			for _, obs := range []string{"1.5", "NaN", "Inf", "+Inf", "-Infinity", "0x1p-2"} {
+				So(w.Write([]string{"1", obs}), ShouldBeNil)
+			}
+			So(w.Close(), ShouldBeNil)
+
+			Convey("Then only the finite observations are written as numbers", func() {
+				f := openXLSX(out.Bytes())
+				So(cellType(f, "Data", "B2"), ShouldEqual, excelize.CellTypeUnset)
+				So(cellType(f, "Data", "B7"), ShouldEqual, excelize.CellTypeUnset)
+				for _, cell := range []string{"B3", "B4", "B5", "B6"} {
+					So(cellType(f, "Data", cell), ShouldEqual, excelize.CellTypeInlineString)
+				}
+
+				data, err := f.GetRows("Data")
+				So(err, ShouldBeNil)
+				So(data[1:], ShouldResemble, [][]string{{"1", "1.5"}, {"1", "NaN"}, {"1", "Inf"}, {"1", "+Inf"}, {"1", "-Infinity"}, {"1", "0.25"}})
+			})
+		})
+	})
+
+	Convey("Given a query response for a blocked table", t, func() {
+		out := &bytes.Buffer{}
+
+		Convey("Then converting it to xlsx fails with the expected error", func() {
+			_, err := cantabular.GraphQLJSONToXLSX(testCtx, strings.NewReader(mockRespBodyTableError), out, nil)
+			So(err, ShouldNotBeNil)
+			So(err.Error(), ShouldContainSubstring, "table blocked")
+		})
+	})
+}
+
+// openXLSX opens the provided xlsx workbook with a real xlsx reader
+func openXLSX(b []byte) *excelize.File {
+	f, err := excelize.OpenReader(bytes.NewReader(b))
+	So(err, ShouldBeNil)
+	return f
+}
+
+// cellType returns the type of the provided cell
+func cellType(f *excelize.File, sheet, cell string) excelize.CellType {
+	t, err := f.GetCellType(sheet, cell)
+	So(err, ShouldBeNil)
+	return t
+}
+
+// mockRespBodyStaticDatasetRules is a successful static dataset query response with rules that is returned from a mocked client for testing
+var mockRespBodyStaticDatasetRules = strings.Replace(mockRespBodyStaticDataset, `"error": null,`, `"error": null,
+				"rules": {
+					"blocked": {"count": 1, "categories": [{"code": "2", "label": "Belfast"}]},
+					"passed": {"count": 2},
+					"evaluated": {"count": 3}
+				},`, 1)
@@ -9,7 +9,7 @@ import (
 
 // Do should be used by clients to log a request to a given service
 // before it is made. If no log.Data is given then the request type
-// is assumed to be GET
+// is assumed to be GET. Use Start to also log the completion of the request.
 func Do(ctx context.Context, action, service, uri string, data ...log.Data) {
 	d := buildLogData(action, uri, data...)
 
@@ -19,7 +19,7 @@ func Do(ctx context.Context, action, service, uri string, data ...log.Data) {
 func buildLogData(action, uri string, data ...log.Data) (d log.Data) {
 	d = log.Data{
 		"action": action,
-		"uri":    uri,
+		"uri":    redactURI(uri, getConfig().RedactedQueryParams),
 	}
 
 	if len(data) == 0 {
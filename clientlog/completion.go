@@ -0,0 +1,185 @@
+package clientlog
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Outcomes of a completed request, included in the completion log data
+const (
+	OutcomeSuccess = "success"
+	OutcomeError   = "error"
+)
+
+// redacted replaces the values of redacted query parameters
+const redacted = "xxxxx"
+
+// ZebedeeRedactedQueryParams are the query parameters that services calling zebedee can configure to be redacted from logged URIs,
+// e.g. the content path provided in its 'uri' query parameter
+var ZebedeeRedactedQueryParams = []string{"uri"}
+
+// Config defines the behaviour of the completion logging
+type Config struct {
+	// SampleRate is the fraction (between 0 and 1) of successful completions that are logged.
+	// If zero or not lower than 1, all of them are logged. Completions with an error outcome are always logged.
+	SampleRate float64
+	// RedactedQueryParams are the query parameters whose values are redacted from logged URIs.
+	// If empty, logged URIs are not redacted.
+	RedactedQueryParams []string
+}
+
+var (
+	cfg      Config
+	cfgMutex sync.RWMutex
+
+	// sample returns true if a successful completion is to be logged for the provided sample rate
+	sample = func(rate float64) bool {
+		return rand.Float64() < rate
+	}
+)
+
+// Configure sets the sample rate and redacted query parameters used by the completion logging.
+// The configuration is process-wide, so it applies to all the clients of a service and should be set once when the service starts.
+// By default all completions are logged and no query parameters are redacted.
+func Configure(c Config) {
+	cfgMutex.Lock()
+	defer cfgMutex.Unlock()
+
+	cfg = Config{
+		SampleRate:          c.SampleRate,
+		RedactedQueryParams: append([]string{}, c.RedactedQueryParams...),
+	}
+}
+
+func getConfig() Config {
+	cfgMutex.RLock()
+	defer cfgMutex.RUnlock()
+
+	return cfg
+}
+
+// Call represents a request to a service that has been logged by Start, and whose completion is logged by Done
+type Call struct {
+	ctx       context.Context
+	action    string
+	service   string
+	uri       string
+	data      []log.Data
+	startedAt time.Time
+}
+
+// Start logs a request to a given service in the same way as Do, and returns a Call
+// that should be used to log the completion of the request
+func Start(ctx context.Context, action, service, uri string, data ...log.Data) *Call {
+	Do(ctx, action, service, uri, data...)
+
+	return &Call{
+		ctx:       ctx,
+		action:    action,
+		service:   service,
+		uri:       uri,
+		data:      data,
+		startedAt: time.Now(),
+	}
+}
+
+// Done logs the completion of the call with the provided response and error, which are the values returned by the client
+func (c *Call) Done(resp *http.Response, err error) {
+	statusCode, size := 0, int64(-1)
+	if resp != nil {
+		statusCode, size = resp.StatusCode, resp.ContentLength
+	}
+	c.Complete(statusCode, size, err)
+}
+
+// Complete logs the completion of the call with its duration, the provided status code and response size in bytes
+// (omitted if negative), and an outcome that is an error if err is not nil or the status code is not successful
+func (c *Call) Complete(statusCode int, size int64, err error) {
+	c.complete(time.Since(c.startedAt), statusCode, size, err)
+}
+
+func (c *Call) complete(duration time.Duration, statusCode int, size int64, err error) {
+	outcome := OutcomeSuccess
+	if err != nil || statusCode >= http.StatusBadRequest {
+		outcome = OutcomeError
+	}
+
+	conf := getConfig()
+	if outcome == OutcomeSuccess && conf.SampleRate > 0 && conf.SampleRate < 1 && !sample(conf.SampleRate) {
+		return
+	}
+
+	d := buildCompletionLogData(c.action, c.uri, duration, statusCode, size, outcome, c.data...)
+	event := fmt.Sprintf("Completed request to service: %s", c.service)
+
+	if err != nil {
+		log.Warn(c.ctx, event, log.FormatErrors([]error{err}), d)
+		return
+	}
+	log.Info(c.ctx, event, d)
+}
+
+// Doer is the interface of the clients that can be used with DoRequest, e.g. dp-net http.Clienter
+type Doer interface {
+	Do(ctx context.Context, req *http.Request) (*http.Response, error)
+}
+
+// DoRequest logs the request to a given service, makes it with the provided client and logs its completion,
+// returning the response and error returned by the client. The method of the request is included in the log data.
+func DoRequest(ctx context.Context, client Doer, req *http.Request, action, service string, data ...log.Data) (*http.Response, error) {
+	data = append([]log.Data{{"method": req.Method}}, data...)
+	call := Start(ctx, action, service, req.URL.String(), data...)
+
+	resp, err := client.Do(ctx, req)
+	call.Done(resp, err)
+
+	return resp, err
+}
+
+func buildCompletionLogData(action, uri string, duration time.Duration, statusCode int, size int64, outcome string, data ...log.Data) log.Data {
+	d := buildLogData(action, uri, data...)
+	d["duration_ms"] = duration.Milliseconds()
+	d["outcome"] = outcome
+	if statusCode > 0 {
+		d["status_code"] = statusCode
+	}
+	if size >= 0 {
+		d["response_size"] = size
+	}
+	return d
+}
+
+// redactURI returns the uri with the values of the provided query parameters redacted
+func redactURI(uri string, params []string) string {
+	if len(params) == 0 || !strings.Contains(uri, "?") {
+		return uri
+	}
+
+	u, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	query := u.Query()
+	changed := false
+	for _, param := range params {
+		if _, ok := query[param]; ok {
+			query.Set(param, redacted)
+			changed = true
+		}
+	}
+	if !changed {
+		return uri
+	}
+
+	u.RawQuery = query.Encode()
+	return u.String()
+}
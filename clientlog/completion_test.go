@@ -0,0 +1,201 @@
+package clientlog
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/log.go/v2/log"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type doerFunc func(ctx context.Context, req *http.Request) (*http.Response, error)
+
+func (f doerFunc) Do(ctx context.Context, req *http.Request) (*http.Response, error) {
+	return f(ctx, req)
+}
+
+// captureEvents redirects the log output while f is called and returns the logged events
+func captureEvents(f func()) []map[string]interface{} {
+	buf := &bytes.Buffer{}
+	log.SetDestination(buf, nil)
+	defer log.SetDestination(os.Stdout, os.Stderr)
+
+	f()
+
+	var events []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var event map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &event); err == nil {
+			events = append(events, event)
+		}
+	}
+	return events
+}
+
+func TestBuildCompletionLogData(t *testing.T) {
+	Convey("Given a completed request with a known size", t, func() {
+		d := buildCompletionLogData("retrieving dataset", "http://localhost:22000/datasets/cpih01", 1500*time.Millisecond, http.StatusOK, 512, OutcomeSuccess)
+
+		Convey("Then the log data contains the request data with its duration, status, size and outcome", func() {
+			So(d, ShouldResemble, log.Data{
+				"action":        "retrieving dataset",
+				"uri":           "http://localhost:22000/datasets/cpih01",
+				"method":        "GET",
+				"duration_ms":   int64(1500),
+				"outcome":       OutcomeSuccess,
+				"status_code":   http.StatusOK,
+				"response_size": int64(512),
+			})
+		})
+	})
+
+	Convey("Given a request that failed without a response", t, func() {
+		d := buildCompletionLogData("creating filter", "http://localhost:22100/filters", time.Second, 0, -1, OutcomeError, log.Data{"method": "POST"})
+
+		Convey("Then the status code and size are omitted", func() {
+			So(d, ShouldNotContainKey, "status_code")
+			So(d, ShouldNotContainKey, "response_size")
+			So(d["method"], ShouldEqual, "POST")
+			So(d["outcome"], ShouldEqual, OutcomeError)
+		})
+	})
+}
+
+func TestRedactURI(t *testing.T) {
+	Convey("Given query parameters to redact", t, func() {
+		params := []string{"uri"}
+
+		Convey("Then their values are redacted from URIs containing them", func() {
+			So(redactURI("http://localhost:8082/data?uri=/economy/inflation&lang=cy", params), ShouldEqual, "http://localhost:8082/data?lang=cy&uri=xxxxx")
+		})
+
+		Convey("Then other URIs are unchanged", func() {
+			So(redactURI("http://localhost:8082/data?lang=cy", params), ShouldEqual, "http://localhost:8082/data?lang=cy")
+			So(redactURI("http://localhost:8082/data", params), ShouldEqual, "http://localhost:8082/data")
+		})
+	})
+
+	Convey("Given no query parameters to redact, then URIs are unchanged", t, func() {
+		So(redactURI("http://localhost:8082/data?uri=/economy", nil), ShouldEqual, "http://localhost:8082/data?uri=/economy")
+	})
+}
+
+func TestDoRequest(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a client that responds successfully and the default configuration", t, func() {
+		client := doerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, ContentLength: 2, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+		})
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:8082/data?uri=/economy", nil)
+		So(err, ShouldBeNil)
+
+		Convey("When the request is made with DoRequest", func() {
+			var resp *http.Response
+			events := captureEvents(func() {
+				resp, err = DoRequest(ctx, client, req, "retrieving content", "zebedee")
+			})
+
+			Convey("Then the response of the client is returned", func() {
+				So(err, ShouldBeNil)
+				So(resp.StatusCode, ShouldEqual, http.StatusOK)
+			})
+
+			Convey("Then the start and completion of the request are logged with the uri unchanged", func() {
+				So(events, ShouldHaveLength, 2)
+				So(events[0]["event"], ShouldEqual, "Making request to service: zebedee")
+				So(events[1]["event"], ShouldEqual, "Completed request to service: zebedee")
+
+				data := events[1]["data"].(map[string]interface{})
+				So(data["uri"], ShouldEqual, "http://localhost:8082/data?uri=/economy")
+				So(data["method"], ShouldEqual, http.MethodGet)
+				So(data["status_code"], ShouldEqual, http.StatusOK)
+				So(data["response_size"], ShouldEqual, 2)
+				So(data["outcome"], ShouldEqual, OutcomeSuccess)
+				So(data, ShouldContainKey, "duration_ms")
+			})
+		})
+	})
+
+	Convey("Given a configuration that redacts the zebedee query parameters", t, func() {
+		Configure(Config{RedactedQueryParams: ZebedeeRedactedQueryParams})
+		defer Configure(Config{})
+
+		client := doerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, ContentLength: -1}, nil
+		})
+		req, err := http.NewRequest(http.MethodGet, "http://localhost:8082/data?uri=/economy", nil)
+		So(err, ShouldBeNil)
+
+		Convey("Then the uri is logged redacted", func() {
+			events := captureEvents(func() {
+				_, err = DoRequest(ctx, client, req, "retrieving content", "zebedee")
+			})
+			So(events, ShouldHaveLength, 2)
+			So(events[1]["data"].(map[string]interface{})["uri"], ShouldEqual, "http://localhost:8082/data?uri=xxxxx")
+		})
+
+		Convey("Then changes to the provided query parameters do not affect the configuration", func() {
+			params := []string{"uri"}
+			Configure(Config{RedactedQueryParams: params})
+			params[0] = "lang"
+			So(getConfig().RedactedQueryParams, ShouldResemble, []string{"uri"})
+		})
+	})
+
+	Convey("Given a client that fails", t, func() {
+		client := doerFunc(func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			return nil, errors.New("connection refused")
+		})
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:22100/filters", nil)
+		So(err, ShouldBeNil)
+
+		Convey("When the request is made with DoRequest", func() {
+			events := captureEvents(func() {
+				_, err = DoRequest(ctx, client, req, "creating filter", "filter-api")
+			})
+
+			Convey("Then the error is returned and the completion is logged as a warning with an error outcome", func() {
+				So(err, ShouldNotBeNil)
+				So(events, ShouldHaveLength, 2)
+				So(events[1]["severity"], ShouldEqual, 2)
+				So(events[1]["data"].(map[string]interface{})["outcome"], ShouldEqual, OutcomeError)
+				So(events[1], ShouldContainKey, "errors")
+			})
+		})
+	})
+}
+
+func TestCompletionSampling(t *testing.T) {
+	ctx := context.Background()
+
+	Convey("Given a sample rate that excludes the completion", t, func() {
+		Configure(Config{SampleRate: 0.1})
+		originalSample := sample
+		sample = func(rate float64) bool { return false }
+		defer func() {
+			Configure(Config{})
+			sample = originalSample
+		}()
+
+		call := &Call{ctx: ctx, action: "retrieving dataset", service: "dataset-api", uri: "http://localhost:22000/datasets", startedAt: time.Now()}
+
+		Convey("Then successful completions are not logged", func() {
+			events := captureEvents(func() { call.Complete(http.StatusOK, 10, nil) })
+			So(events, ShouldBeEmpty)
+		})
+
+		Convey("Then completions with an error outcome are logged", func() {
+			events := captureEvents(func() { call.Complete(http.StatusInternalServerError, 10, nil) })
+			So(events, ShouldHaveLength, 1)
+		})
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -29,6 +30,7 @@ type ErrInvalidCodelistAPIResponse struct {
 	expectedCode int
 	actualCode   int
 	uri          string
+	response     dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -45,6 +47,15 @@ func (e ErrInvalidCodelistAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from codelist api
+func (e ErrInvalidCodelistAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["expected_code"] = e.expectedCode
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 // New creates a new instance of Client with a given filter api url
 func New(codelistAPIURL string) *Client {
 	return &Client{
@@ -55,8 +66,11 @@ func New(codelistAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of CodelistAPI Client,
 // reusing the URL and Clienter from the provided healthcheck client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -88,7 +102,7 @@ func (c *Client) GetValues(ctx context.Context, userAuthToken string, serviceAut
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		err = &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return vals, err
 	}
 
@@ -113,7 +127,7 @@ func (c *Client) GetIDNameMap(ctx context.Context, userAuthToken string, service
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return nil, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	body, err := ioutil.ReadAll(resp.Body)
@@ -147,7 +161,7 @@ func (c *Client) GetGeographyCodeLists(ctx context.Context, userAuthToken string
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return results, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return results, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
@@ -176,7 +190,7 @@ func (c *Client) GetCodeListEditions(ctx context.Context, userAuthToken string,
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != 200 {
-		return editionsList, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return editionsList, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
@@ -206,7 +220,7 @@ func (c *Client) GetCodes(ctx context.Context, userAuthToken string, serviceAuth
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return codes, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return codes, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
@@ -236,7 +250,7 @@ func (c *Client) GetCodeByID(ctx context.Context, userAuthToken string, serviceA
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return code, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return code, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
@@ -266,7 +280,7 @@ func (c *Client) GetDatasetsByCode(ctx context.Context, userAuthToken string, se
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return datasets, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return datasets, &ErrInvalidCodelistAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -294,7 +295,7 @@ func TestClient_GetValues(t *testing.T) {
 		codelistClient := NewWithHealthClient(hcCli)
 
 		expectedURI := fmt.Sprintf("%s/code-lists/%s/codes", testHost, "999")
-		expectedErr := &ErrInvalidCodelistAPIResponse{http.StatusOK, 500, expectedURI}
+		expectedErr := &ErrInvalidCodelistAPIResponse{expectedCode: http.StatusOK, actualCode: 500, uri: expectedURI}
 
 		dimensionValues, err := codelistClient.GetValues(nil, testUserAuthToken, testServiceAuthToken, "999")
 
@@ -309,6 +310,22 @@ func TestClient_GetValues(t *testing.T) {
 		So(body.IsClosed, ShouldBeTrue)
 	})
 
+	Convey("should return an error with the response body in its log data for a non 200 response status with a body", t, func() {
+		body := httpmocks.NewReadCloserMock([]byte("code list not found"), nil)
+		resp := httpmocks.NewResponseMock(body, http.StatusNotFound)
+
+		clienter := getClienterMock(resp, nil)
+		hcCli := health.NewClientWithClienter("", testHost, clienter)
+		codelistClient := NewWithHealthClient(hcCli)
+
+		_, err := codelistClient.GetValues(nil, testUserAuthToken, testServiceAuthToken, "999")
+
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidCodelistAPIResponse{})
+		So(err.(*ErrInvalidCodelistAPIResponse).Code(), ShouldEqual, http.StatusNotFound)
+		So(dperrors.LogData(err)["response_body"], ShouldEqual, "code list not found")
+		So(body.IsClosed, ShouldBeTrue)
+	})
+
 	Convey("should return expected error if ioutil.ReadAll returns an error", t, func() {
 		expectedErr := errors.New("lets get schwifty")
 		body := httpmocks.NewReadCloserMock(nil, expectedErr)
@@ -859,9 +876,9 @@ func TestClient_GetCodes(t *testing.T) {
 			Convey("then the expected error is returned", func() {
 				So(actual, ShouldResemble, CodesResults{})
 				So(err, ShouldResemble, &ErrInvalidCodelistAPIResponse{
-					http.StatusOK,
-					http.StatusInternalServerError,
-					"http://" + host + uri,
+					expectedCode: http.StatusOK,
+					actualCode:   http.StatusInternalServerError,
+					uri:          "http://" + host + uri,
 				})
 			})
 
@@ -1005,9 +1022,9 @@ func TestClient_GetCodeByID(t *testing.T) {
 
 	Convey("given clienter.Do returns a non 200 status response", t, func() {
 		expectedErr := &ErrInvalidCodelistAPIResponse{
-			http.StatusOK,
-			http.StatusInternalServerError,
-			"http://" + host + uri,
+			expectedCode: http.StatusOK,
+			actualCode:   http.StatusInternalServerError,
+			uri:          "http://" + host + uri,
 		}
 
 		body := httpmocks.NewReadCloserMock([]byte{}, nil)
@@ -1166,9 +1183,9 @@ func TestClient_GetDatasetsByCode(t *testing.T) {
 
 	Convey("given clienter.Do returns a non 200 response status", t, func() {
 		expectedErr := &ErrInvalidCodelistAPIResponse{
-			http.StatusOK,
-			http.StatusInternalServerError,
-			"http://" + host + uri,
+			expectedCode: http.StatusOK,
+			actualCode:   http.StatusInternalServerError,
+			uri:          "http://" + host + uri,
 		}
 
 		body := httpmocks.NewReadCloserMock(make([]byte, 0), nil)
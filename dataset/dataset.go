@@ -14,6 +14,7 @@ import (
 	"strings"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/batch"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -61,6 +62,7 @@ type ErrInvalidDatasetAPIResponse struct {
 	actualCode int
 	uri        string
 	body       string
+	response   dperrors.ResponseContext
 }
 
 // DatasetsBatchProcessor is the type corresponding to a batch processing function for a dataset List.
@@ -92,6 +94,14 @@ func (e ErrInvalidDatasetAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from dataset api
+func (e ErrInvalidDatasetAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 var _ error = ErrInvalidDatasetAPIResponse{}
 
 // Client is a dataset api client which can be used to make requests to the server
@@ -131,8 +141,11 @@ func NewAPIClient(datasetAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -1366,6 +1379,7 @@ func NewDatasetAPIResponse(resp *http.Response, uri string) (e *ErrInvalidDatase
 	e = &ErrInvalidDatasetAPIResponse{
 		actualCode: resp.StatusCode,
 		uri:        uri,
+		response:   dperrors.NewResponseContext(resp),
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		b, err := ioutil.ReadAll(resp.Body)
@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	. "github.com/smartystreets/goconvey/convey"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -566,10 +567,15 @@ func TestClient_GetDatasets(t *testing.T) {
 					actualCode: 404,
 					uri:        fmt.Sprintf("http://localhost:8080/datasets"),
 					body:       "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}",
+					response:   dperrors.ResponseContext{BodySnippet: "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}"},
 				})
 				So(options, ShouldResemble, List{})
 			})
 
+			Convey("the response body is included in the log data of the error", func() {
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}")
+			})
+
 			Convey("and dphttpclient.Do is called 1 time with the expected URI", func() {
 				expectedURI := fmt.Sprintf("/datasets")
 				expectedHeaders := expectedHeaders{
@@ -990,6 +996,7 @@ func TestClient_GetFullEditionsDetails(t *testing.T) {
 					actualCode: http.StatusNotFound,
 					uri:        "http://localhost:8080/datasets/123/editions",
 					body:       "null",
+					response:   dperrors.ResponseContext{BodySnippet: "null"},
 				})
 			})
 
@@ -1016,6 +1023,7 @@ func TestClient_GetFullEditionsDetails(t *testing.T) {
 					actualCode: http.StatusInternalServerError,
 					uri:        "http://localhost:8080/datasets/123/editions",
 					body:       "",
+					response:   dperrors.ResponseContext{BodySnippet: "null"},
 				})
 			})
 
@@ -1254,6 +1262,7 @@ func TestClient_PostInstance(t *testing.T) {
 					actualCode: http.StatusBadRequest,
 					uri:        "http://localhost:8080/instances",
 					body:       "",
+					response:   dperrors.ResponseContext{BodySnippet: "\"\""},
 				})
 			})
 		})
@@ -1725,6 +1734,7 @@ func TestClient_GetInstanceDimensions(t *testing.T) {
 					actualCode: http.StatusNotFound,
 					uri:        "http://localhost:8080/instances/123/dimensions",
 					body:       "null",
+					response:   dperrors.ResponseContext{BodySnippet: "null"},
 				})
 			})
 
@@ -2001,6 +2011,7 @@ func TestClient_PatchInstanceDimensionOption(t *testing.T) {
 					actualCode: http.StatusNotFound,
 					uri:        "http://localhost:8080/instances/123/dimensions/456/options/789",
 					body:       "null",
+					response:   dperrors.ResponseContext{BodySnippet: "null"},
 				})
 			})
 
@@ -2138,6 +2149,7 @@ func TestClient_GetOptions(t *testing.T) {
 					actualCode: 404,
 					uri:        fmt.Sprintf("http://localhost:8080/datasets/%s/editions/%s/versions/%s/dimensions/%s/options", instanceID, edition, version, dimension),
 					body:       "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}",
+					response:   dperrors.ResponseContext{BodySnippet: "{\"items\":null,\"count\":0,\"offset\":0,\"limit\":0,\"total_count\":0}"},
 				})
 				So(options, ShouldResemble, Options{})
 			})
@@ -2435,6 +2447,7 @@ func TestClient_PatchInstanceDimensions(t *testing.T) {
 					actualCode: http.StatusNotFound,
 					uri:        "http://localhost:8080/instances/123/dimensions",
 					body:       "null",
+					response:   dperrors.ResponseContext{BodySnippet: "null"},
 				})
 			})
 
@@ -45,6 +45,7 @@ func NewClient(dimensionsAPIURL string) (*Client, error) {
 // reusing the URL and Clienter from the provided health check client
 func NewWithHealthClient(hcCli *health.Client) (*Client, error) {
 	client := health.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	client.LatencyThresholds = hcCli.LatencyThresholds
 	baseURL, err := url.Parse(client.URL)
 	if err != nil {
 		return nil, errors.Wrap(err, "error parsing URL")
@@ -11,6 +11,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -40,8 +41,11 @@ func NewAPIClient(downloadServiceAPIURL, serviceAuthToken string) *Client {
 // NewWithHealthClient creates a new instance of DownloadServiceAPI Client,
 // reusing the URL and Clienter from the provided healthcheck client.
 func NewWithHealthClient(hcCli *healthcheck.Client, serviceAuthToken string) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 		serviceAuthToken,
 	}
 }
@@ -86,6 +90,18 @@ func (c *Client) Download(ctx context.Context, path string) (*Response, error) {
 	return &Response{Content: resp.Body}, nil
 }
 
+// DownloadWithMeter returns the requested path, with its content read through the provided meter,
+// e.g. to report the progress of a large download, calculate its checksum or limit its size
+func (c *Client) DownloadWithMeter(ctx context.Context, path string, m *stream.Meter) (*Response, error) {
+	resp, err := c.Download(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Content = m.ReadCloser(resp.Content)
+	return resp, nil
+}
+
 func (c *Client) doGetWithAuthHeaders(ctx context.Context, uri string) (*http.Response, error) {
 	clientlog.Do(ctx, "retrieving resource", service, uri)
 
@@ -2,6 +2,7 @@ package download_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"net/http"
@@ -12,6 +13,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/download"
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 
@@ -107,6 +109,19 @@ func TestDownload(t *testing.T) {
 				So(actualContent, ShouldEqual, content)
 			})
 		})
+
+		Convey("When I download with a meter", func() {
+			m := stream.NewMeter(stream.MeterConfig{SHA256: true})
+			resp, err := c.DownloadWithMeter(context.Background(), filepath, m)
+			content := readAndClose(resp)
+
+			Convey("Then the content is read through the meter", func() {
+				So(err, ShouldBeNil)
+				So(content, ShouldEqual, actualContent)
+				So(m.Progress().Bytes, ShouldEqual, len(actualContent))
+				So(m.SHA256(), ShouldEqual, fmt.Sprintf("%x", sha256.Sum256([]byte(actualContent))))
+			})
+		})
 	})
 
 	Convey("Given a file responds as expected from a redirect", t, func() {
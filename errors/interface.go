@@ -14,3 +14,17 @@ type coder interface {
 type dataLogger interface {
 	LogData() map[string]interface{}
 }
+
+// statusCoder is an interface that allows you to extract
+// a http status code from an error that names it StatusCode(),
+// such as the errors returned by Cantabular GraphQL queries
+type statusCoder interface {
+	StatusCode() int
+}
+
+// explicitStatusCoder is an interface that allows a statusCoder to report
+// whether its status code was explicitly provided or is only a default,
+// such as a Cantabular GraphQL error without a status in its message
+type explicitStatusCoder interface {
+	HasStatusCode() bool
+}
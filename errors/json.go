@@ -1,13 +1,36 @@
 package errors
 
 import (
+	"bytes"
 	"encoding/json"
 	navtiveErrors "errors"
 	"fmt"
 	"io"
+	"mime"
+	"net/http"
 	"strings"
 )
 
+const (
+	// maxErrorBodySize is the maximum number of bytes read from an error response body
+	maxErrorBodySize = 64 * 1024
+
+	// maxBodySnippetSize is the maximum number of bytes of an error response body included in log data
+	maxBodySnippetSize = 1024
+
+	// problemJSONContentType is the media type of RFC 7807 problem details
+	problemJSONContentType = "application/problem+json"
+)
+
+// Formats of error response bodies understood by FromBody and FromResponse, included in the log data of the parsed errors
+const (
+	FormatJSONErrors = "json_errors"
+	FormatProblem    = "problem"
+	FormatMessage    = "message"
+	FormatText       = "text"
+	FormatEmpty      = "empty"
+)
+
 type JsonError struct {
 	Code        string `json:"errorCode"`
 	Description string `json:"description"`
@@ -17,6 +40,29 @@ type JsonErrors struct {
 	Errors []JsonError `json:"errors"`
 }
 
+// Problem represents an RFC 7807 problem details object
+type Problem struct {
+	Type     string `json:"type,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Status   int    `json:"status,omitempty"`
+	Detail   string `json:"detail,omitempty"`
+	Instance string `json:"instance,omitempty"`
+}
+
+// errorBody represents all the supported JSON error body shapes
+type errorBody struct {
+	Errors  []json.RawMessage `json:"errors"`
+	Message string            `json:"message"`
+	Problem
+}
+
+// errorItem represents an item of an "errors" array, which can be a dp JsonError, a GraphQL error or a plain string
+type errorItem struct {
+	Code        string `json:"errorCode"`
+	Description string `json:"description"`
+	Message     string `json:"message"`
+}
+
 func (j JsonErrors) ToNativeError() error {
 	var msgs []string
 	for _, e := range j.Errors {
@@ -25,10 +71,150 @@ func (j JsonErrors) ToNativeError() error {
 	return navtiveErrors.New(strings.Join(msgs, "\n"))
 }
 
+// FromBody parses an error response body and returns it as an *Error. The following formats are understood:
+//   - dp errors, e.g. {"errors":[{"errorCode":"...","description":"..."}]}
+//   - lists of error messages, e.g. {"errors":["..."]} as returned by filter flex API, or GraphQL {"errors":[{"message":"..."}]}
+//   - RFC 7807 problem details, e.g. {"title":"...","detail":"...","status":400}
+//   - single messages, e.g. {"message":"..."} as returned by Cantabular
+//   - plain text
+//
+// As the response status code is unknown, the status code of the returned error is only set for problem details that
+// contain one. Use FromResponse to capture the response status code.
 func FromBody(body io.Reader) error {
-	je := JsonErrors{}
-	if err := json.NewDecoder(body).Decode(&je); err != nil {
-		return err
+	return parseErrorBody(body, "", 0)
+}
+
+// FromResponse parses the body of an error response in any of the formats understood by FromBody and returns it as an
+// *Error with the response status code, and the request method and URL in its log data. The body is not closed.
+func FromResponse(resp *http.Response) *Error {
+	if resp == nil {
+		return New(navtiveErrors.New("nil response"), http.StatusInternalServerError, nil)
+	}
+
+	var body io.Reader = http.NoBody
+	if resp.Body != nil {
+		body = resp.Body
+	}
+
+	e := parseErrorBody(body, resp.Header.Get("Content-Type"), resp.StatusCode)
+	if resp.Request != nil && resp.Request.URL != nil {
+		e.logData["method"] = resp.Request.Method
+		e.logData["url"] = RedactURL(resp.Request.URL)
+	}
+	return e
+}
+
+// parseErrorBody reads up to maxErrorBodySize bytes of the body and creates an *Error from it
+func parseErrorBody(body io.Reader, contentType string, statusCode int) *Error {
+	logData := map[string]interface{}{}
+	if statusCode > 0 {
+		logData["status_code"] = statusCode
+	}
+	if contentType != "" {
+		logData["content_type"] = contentType
+	}
+
+	b, err := io.ReadAll(io.LimitReader(body, maxErrorBodySize))
+	if err != nil {
+		return New(fmt.Errorf("failed to read error response body: %w", err), statusCode, logData)
+	}
+
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 {
+		logData["response_body"] = string(bodySnippet(b))
+	}
+
+	format, message, bodyStatus := parseErrorMessage(b, contentType)
+	logData["error_format"] = format
+
+	if statusCode == 0 {
+		statusCode = bodyStatus
+	}
+	if message == "" {
+		message = fallbackMessage(statusCode)
+	}
+
+	return New(navtiveErrors.New(message), statusCode, logData)
+}
+
+// parseErrorMessage returns the format, message and (if present) status code of the provided error body
+func parseErrorMessage(b []byte, contentType string) (format, message string, statusCode int) {
+	if len(b) == 0 {
+		return FormatEmpty, "", 0
+	}
+
+	var eb errorBody
+	if err := json.Unmarshal(b, &eb); err != nil {
+		return FormatText, string(bodySnippet(b)), 0
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	isProblem := mediaType == problemJSONContentType || eb.Title != "" || eb.Detail != ""
+
+	switch {
+	case len(eb.Errors) > 0:
+		return FormatJSONErrors, errorItemsMessage(eb.Errors), 0
+	case isProblem:
+		return FormatProblem, problemMessage(eb.Problem), eb.Status
+	case eb.Message != "":
+		return FormatMessage, eb.Message, 0
+	default:
+		return FormatText, string(bodySnippet(b)), 0
+	}
+}
+
+// errorItemsMessage joins the messages of all the items of an "errors" array
+func errorItemsMessage(items []json.RawMessage) string {
+	var msgs []string
+	for _, raw := range items {
+		var s string
+		if err := json.Unmarshal(raw, &s); err == nil {
+			msgs = append(msgs, s)
+			continue
+		}
+
+		var item errorItem
+		if err := json.Unmarshal(raw, &item); err != nil {
+			msgs = append(msgs, string(raw))
+			continue
+		}
+
+		switch {
+		case item.Code != "" || item.Description != "":
+			msgs = append(msgs, fmt.Sprintf("%s: %s", item.Code, item.Description))
+		case item.Message != "":
+			msgs = append(msgs, item.Message)
+		default:
+			msgs = append(msgs, string(raw))
+		}
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// problemMessage returns a message describing the provided problem details
+func problemMessage(p Problem) string {
+	switch {
+	case p.Title != "" && p.Detail != "":
+		return p.Title + ": " + p.Detail
+	case p.Detail != "":
+		return p.Detail
+	default:
+		return p.Title
+	}
+}
+
+// fallbackMessage returns a message for error responses without a usable body
+func fallbackMessage(statusCode int) string {
+	if text := http.StatusText(statusCode); text != "" {
+		return fmt.Sprintf("%d %s: empty error response body", statusCode, text)
+	}
+	return "empty error response body"
+}
+
+// bodySnippet returns the provided body truncated to maxBodySnippetSize bytes
+func bodySnippet(b []byte) []byte {
+	if len(b) <= maxBodySnippetSize {
+		return b
 	}
-	return je.ToNativeError()
+	return append(b[:maxBodySnippetSize:maxBodySnippetSize], "..."...)
 }
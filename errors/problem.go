@@ -0,0 +1,159 @@
+package errors
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// aboutBlank is the problem type used when no type base URI is configured, as defined by RFC 7807
+const aboutBlank = "about:blank"
+
+// problemMembers are the RFC 7807 members, which cannot be overwritten by extension members
+var problemMembers = map[string]struct{}{
+	"type":     {},
+	"title":    {},
+	"status":   {},
+	"detail":   {},
+	"instance": {},
+}
+
+// ProblemWriter writes errors as RFC 7807 problem details (application/problem+json).
+// The status is extracted from errors implementing Code() and extension members from errors implementing LogData(),
+// so that *Error and the ErrInvalid*Response errors returned by the clients are written consistently.
+type ProblemWriter struct {
+	// TypeBaseURI is prefixed to a slug of the status text to create the problem type,
+	// e.g. "https://api.ons.gov.uk/problems/" results in "https://api.ons.gov.uk/problems/not-found".
+	// If empty, the type is "about:blank".
+	TypeBaseURI string
+	// AllowedFields contains the log data keys that are written as extension members.
+	// Any other log data is never exposed to callers.
+	AllowedFields []string
+	// IncludeServerErrorDetail writes the error message as the detail of 5xx problems.
+	// By default it is only written for 4xx problems, so that downstream failures are not leaked.
+	IncludeServerErrorDetail bool
+}
+
+// NewProblemWriter creates a new ProblemWriter with the provided type base URI and allowed log data fields
+func NewProblemWriter(typeBaseURI string, allowedFields ...string) *ProblemWriter {
+	return &ProblemWriter{
+		TypeBaseURI:   typeBaseURI,
+		AllowedFields: allowedFields,
+	}
+}
+
+// WriteProblem writes the provided error as problem details with an "about:blank" type and no extension members
+func WriteProblem(w http.ResponseWriter, req *http.Request, err error) {
+	(&ProblemWriter{}).Write(w, req, err)
+}
+
+// Write writes the provided error as problem details, using the request ID of the request as the problem instance
+func (p *ProblemWriter) Write(w http.ResponseWriter, req *http.Request, err error) {
+	ctx := context.Background()
+	if req != nil {
+		ctx = req.Context()
+	}
+
+	problem := p.Problem(req, err)
+	b, marshalErr := json.Marshal(problem)
+	if marshalErr != nil {
+		log.Error(ctx, "failed to marshal problem details", marshalErr, log.Data{"status": problem["status"]})
+		w.WriteHeader(problem["status"].(int))
+		return
+	}
+
+	w.Header().Set("Content-Type", problemJSONContentType)
+	w.WriteHeader(problem["status"].(int))
+	if _, writeErr := w.Write(b); writeErr != nil {
+		log.Error(ctx, "failed to write problem details", writeErr)
+	}
+}
+
+// Problem returns the problem details members for the provided error and request, including the allowed extension members
+func (p *ProblemWriter) Problem(req *http.Request, err error) map[string]interface{} {
+	status := problemStatus(err)
+
+	problem := map[string]interface{}{}
+	for k, v := range p.extensions(err) {
+		problem[k] = v
+	}
+
+	problem["type"] = p.problemType(status)
+	problem["title"] = http.StatusText(status)
+	problem["status"] = status
+
+	if err != nil && (status < http.StatusInternalServerError || p.IncludeServerErrorDetail) {
+		problem["detail"] = err.Error()
+	}
+
+	if instance := requestID(req); instance != "" {
+		problem["instance"] = instance
+	}
+
+	return problem
+}
+
+// extensions returns the allowed log data of the error, which must be valid JSON to be included
+func (p *ProblemWriter) extensions(err error) map[string]interface{} {
+	if err == nil || len(p.AllowedFields) == 0 {
+		return nil
+	}
+
+	logData := UnwrapLogData(err)
+	for k, v := range LogData(err) {
+		if _, ok := logData[k]; !ok {
+			logData[k] = v
+		}
+	}
+
+	extensions := map[string]interface{}{}
+	for _, field := range p.AllowedFields {
+		if _, ok := problemMembers[field]; ok {
+			continue
+		}
+		v, ok := logData[field]
+		if !ok {
+			continue
+		}
+		if _, err := json.Marshal(v); err != nil {
+			continue
+		}
+		extensions[field] = v
+	}
+	return extensions
+}
+
+// problemType returns the type URI for the provided status
+func (p *ProblemWriter) problemType(status int) string {
+	if p.TypeBaseURI == "" {
+		return aboutBlank
+	}
+	slug := strings.ToLower(strings.ReplaceAll(http.StatusText(status), " ", "-"))
+	slug = strings.NewReplacer("'", "", "(", "", ")", "").Replace(slug)
+	return strings.TrimSuffix(p.TypeBaseURI, "/") + "/" + slug
+}
+
+// problemStatus returns the status code of the error, defaulting to 500 for any code that is not an error status
+func problemStatus(err error) int {
+	status := StatusCode(err)
+	if status < http.StatusBadRequest || status > 599 || http.StatusText(status) == "" {
+		return http.StatusInternalServerError
+	}
+	return status
+}
+
+// requestID returns the request ID from the request header or, if not present, the request context
+func requestID(req *http.Request) string {
+	if req == nil {
+		return ""
+	}
+	if id, err := headers.GetRequestID(req); err == nil {
+		return id
+	}
+	return dprequest.GetRequestId(req.Context())
+}
@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testInvalidResponseError struct {
+	actualCode int
+}
+
+func (e testInvalidResponseError) Error() string {
+	return fmt.Sprintf("invalid response: %d", e.actualCode)
+}
+
+func (e testInvalidResponseError) Code() int {
+	return e.actualCode
+}
+
+func (e testInvalidResponseError) LogData() map[string]interface{} {
+	return map[string]interface{}{"actual_code": e.actualCode, "uri": "http://localhost:22000/datasets/cpih01"}
+}
+
+func writeProblem(p *ProblemWriter, req *http.Request, err error) (*httptest.ResponseRecorder, map[string]interface{}) {
+	rr := httptest.NewRecorder()
+	p.Write(rr, req, err)
+
+	var body map[string]interface{}
+	So(json.Unmarshal(rr.Body.Bytes(), &body), ShouldBeNil)
+	return rr, body
+}
+
+func TestProblemWriter(t *testing.T) {
+	Convey("Given a problem writer with a type base URI and allowed fields", t, func() {
+		p := NewProblemWriter("https://api.ons.gov.uk/problems/", "uri", "status", "response_body")
+		req := httptest.NewRequest(http.MethodGet, "/datasets", nil)
+		req.Header.Set("X-Request-Id", "request-123")
+
+		Convey("When a 4xx *Error is written", func() {
+			err := New(errors.New("dataset not found"), http.StatusNotFound, map[string]interface{}{
+				"uri":    "http://localhost:22000/datasets/cpih01",
+				"secret": "token",
+				"status": 200,
+			})
+			rr, body := writeProblem(p, req, err)
+
+			Convey("Then the problem details are written with the error status", func() {
+				So(rr.Code, ShouldEqual, http.StatusNotFound)
+				So(rr.Header().Get("Content-Type"), ShouldEqual, "application/problem+json")
+				So(body["type"], ShouldEqual, "https://api.ons.gov.uk/problems/not-found")
+				So(body["title"], ShouldEqual, "Not Found")
+				So(body["status"], ShouldEqual, http.StatusNotFound)
+				So(body["detail"], ShouldEqual, "dataset not found")
+				So(body["instance"], ShouldEqual, "request-123")
+			})
+
+			Convey("Then only the allowed log data is written, without overwriting standard members", func() {
+				So(body["uri"], ShouldEqual, "http://localhost:22000/datasets/cpih01")
+				So(body, ShouldNotContainKey, "secret")
+				So(body, ShouldNotContainKey, "response_body")
+			})
+		})
+
+		Convey("When a wrapped 5xx error is written", func() {
+			err := fmt.Errorf("failed to get dataset: %w", New(errors.New("connection refused"), http.StatusBadGateway, map[string]interface{}{"uri": "http://localhost:22000"}))
+			rr, body := writeProblem(p, req, err)
+
+			Convey("Then the status and log data are extracted from the wrapped error and the detail is not exposed", func() {
+				So(rr.Code, ShouldEqual, http.StatusBadGateway)
+				So(body["type"], ShouldEqual, "https://api.ons.gov.uk/problems/bad-gateway")
+				So(body["uri"], ShouldEqual, "http://localhost:22000")
+				So(body, ShouldNotContainKey, "detail")
+			})
+
+			Convey("And when server error details are enabled, then the detail is written", func() {
+				p.IncludeServerErrorDetail = true
+				_, body := writeProblem(p, req, err)
+				So(body["detail"], ShouldEqual, "failed to get dataset: connection refused")
+			})
+		})
+
+		Convey("When an invalid response error implementing Code and LogData is written", func() {
+			rr, body := writeProblem(p, req, testInvalidResponseError{actualCode: http.StatusTooManyRequests})
+
+			Convey("Then its status and allowed log data are written", func() {
+				So(rr.Code, ShouldEqual, http.StatusTooManyRequests)
+				So(body["type"], ShouldEqual, "https://api.ons.gov.uk/problems/too-many-requests")
+				So(body["detail"], ShouldEqual, "invalid response: 429")
+				So(body["uri"], ShouldEqual, "http://localhost:22000/datasets/cpih01")
+				So(body, ShouldNotContainKey, "actual_code")
+			})
+		})
+
+		Convey("When an error with a non-error status code is written", func() {
+			rr, body := writeProblem(p, req, New(errors.New("unexpected"), http.StatusOK, nil))
+
+			Convey("Then it is written as an internal server error", func() {
+				So(rr.Code, ShouldEqual, http.StatusInternalServerError)
+				So(body["title"], ShouldEqual, "Internal Server Error")
+			})
+		})
+	})
+
+	Convey("Given a request without a request ID header but with a request ID in its context", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "/datasets", nil)
+		req = req.WithContext(dprequest.WithRequestId(req.Context(), "context-request-id"))
+
+		Convey("When an error without a status code is written with WriteProblem", func() {
+			rr := httptest.NewRecorder()
+			WriteProblem(rr, req, errors.New("plain error"))
+
+			var body map[string]interface{}
+			So(json.Unmarshal(rr.Body.Bytes(), &body), ShouldBeNil)
+
+			Convey("Then an about:blank internal server error is written with the request ID from the context", func() {
+				So(rr.Code, ShouldEqual, http.StatusInternalServerError)
+				So(body["type"], ShouldEqual, "about:blank")
+				So(body["instance"], ShouldEqual, "context-request-id")
+				So(body, ShouldNotContainKey, "detail")
+			})
+		})
+	})
+}
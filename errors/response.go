@@ -0,0 +1,125 @@
+package errors
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+)
+
+// redacted replaces the values of sensitive query parameters
+const redacted = "xxxxx"
+
+// HeadersOfInterest are the response headers captured by NewResponseContext
+var HeadersOfInterest = []string{"Content-Type", "ETag", "Location", "Retry-After", "X-Request-Id"}
+
+// sensitiveQueryParams are the (lower case) query parameters whose values are redacted from captured URLs
+var sensitiveQueryParams = map[string]struct{}{
+	"access_token":     {},
+	"api_key":          {},
+	"key":              {},
+	"password":         {},
+	"secret":           {},
+	"signature":        {},
+	"token":            {},
+	"x-amz-credential": {},
+	"x-amz-signature":  {},
+}
+
+// ResponseContext holds the details of an unexpected response that are useful when debugging a client error
+type ResponseContext struct {
+	Method      string
+	URL         string
+	RequestID   string
+	Headers     map[string]string
+	BodySnippet string
+}
+
+// NewResponseContext captures the method, URL without secrets and request ID of the request of the provided response,
+// along with its headers of interest and a bounded snippet of its body.
+// The body can still be fully read after calling NewResponseContext and it is not closed.
+func NewResponseContext(resp *http.Response) ResponseContext {
+	var c ResponseContext
+	if resp == nil {
+		return c
+	}
+
+	if req := resp.Request; req != nil {
+		c.Method = req.Method
+		if req.URL != nil {
+			c.URL = RedactURL(req.URL)
+		}
+		if id, err := headers.GetRequestID(req); err == nil {
+			c.RequestID = id
+		} else {
+			c.RequestID = dprequest.GetRequestId(req.Context())
+		}
+	}
+
+	for _, name := range HeadersOfInterest {
+		if v := resp.Header.Get(name); v != "" {
+			if c.Headers == nil {
+				c.Headers = map[string]string{}
+			}
+			c.Headers[name] = v
+		}
+	}
+
+	if resp.Body != nil && resp.Body != http.NoBody {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, maxBodySnippetSize+1))
+		resp.Body = struct {
+			io.Reader
+			io.Closer
+		}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+		c.BodySnippet = string(bodySnippet(bytes.TrimSpace(b)))
+	}
+
+	return c
+}
+
+// LogData returns the captured details as log data, omitting any that are empty
+func (c ResponseContext) LogData() map[string]interface{} {
+	logData := map[string]interface{}{}
+	if c.Method != "" {
+		logData["method"] = c.Method
+	}
+	if c.URL != "" {
+		logData["url"] = c.URL
+	}
+	if c.RequestID != "" {
+		logData["request_id"] = c.RequestID
+	}
+	if len(c.Headers) > 0 {
+		logData["response_headers"] = c.Headers
+	}
+	if c.BodySnippet != "" {
+		logData["response_body"] = c.BodySnippet
+	}
+	return logData
+}
+
+// RedactURL returns the provided URL with any password and the values of sensitive query parameters redacted
+func RedactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+
+	redactedURL := *u
+	query := redactedURL.Query()
+	changed := false
+	for k := range query {
+		if _, ok := sensitiveQueryParams[strings.ToLower(k)]; ok {
+			query[k] = []string{redacted}
+			changed = true
+		}
+	}
+	if changed {
+		redactedURL.RawQuery = query.Encode()
+	}
+
+	return redactedURL.Redacted()
+}
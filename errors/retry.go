@@ -0,0 +1,130 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"syscall"
+)
+
+// retryableStatusCodes are the response status codes that indicate a temporary failure
+var retryableStatusCodes = map[int]struct{}{
+	http.StatusTooManyRequests:    {},
+	http.StatusBadGateway:         {},
+	http.StatusServiceUnavailable: {},
+	http.StatusGatewayTimeout:     {},
+}
+
+// unprocessedStatusCodes are the retryable status codes that indicate that the request was not processed,
+// so that it can be retried regardless of its method
+var unprocessedStatusCodes = map[int]struct{}{
+	http.StatusTooManyRequests:    {},
+	http.StatusServiceUnavailable: {},
+}
+
+// idempotentMethods are the HTTP methods that can be safely repeated, as defined by RFC 7231
+var idempotentMethods = map[string]struct{}{
+	http.MethodGet:     {},
+	http.MethodHead:    {},
+	http.MethodOptions: {},
+	http.MethodTrace:   {},
+	http.MethodPut:     {},
+	http.MethodDelete:  {},
+}
+
+// IsTemporary returns true if the error was caused by a temporary condition: a network timeout,
+// a reset or refused connection, or a 429, 502, 503 or 504 status code returned by any error in the chain
+// implementing Code() (e.g. *Error and the ErrInvalid*Response errors) or StatusCode() (e.g. Cantabular gql.Error).
+// Errors whose HasStatusCode() returns false, such as gql errors without a status in their message, do not provide a status code.
+// A network failure anywhere in the chain is temporary regardless of the status code it was wrapped with,
+// e.g. by the Cantabular client, which wraps transport errors in an *Error with a 500 status code
+func IsTemporary(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	if isNetworkFailure(err) {
+		return true
+	}
+
+	if statusCode, ok := errorStatusCode(err); ok {
+		return IsRetryableStatus(statusCode)
+	}
+
+	return false
+}
+
+// IsRetryable returns true if the operation that returned the error can be expected to succeed if it is retried,
+// which is the case for temporary errors, unless the operation was cancelled by the caller
+func IsRetryable(err error) bool {
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	return IsTemporary(err)
+}
+
+// IsRetryableRequest returns true if a request with the provided HTTP method that failed with the error is retryable and
+// safe to retry. Requests with idempotent methods are safe to retry, while any other request is only safe to retry
+// if it was not processed, i.e. the connection was refused or a 429 or 503 status code was returned.
+func IsRetryableRequest(method string, err error) bool {
+	if !IsRetryable(err) {
+		return false
+	}
+
+	if IsIdempotentMethod(method) || errors.Is(err, syscall.ECONNREFUSED) {
+		return true
+	}
+
+	if statusCode, ok := errorStatusCode(err); ok && !isNetworkFailure(err) {
+		_, unprocessed := unprocessedStatusCodes[statusCode]
+		return unprocessed
+	}
+
+	return false
+}
+
+// IsRetryableStatus returns true if the provided status code indicates a temporary failure
+func IsRetryableStatus(statusCode int) bool {
+	_, ok := retryableStatusCodes[statusCode]
+	return ok
+}
+
+// IsIdempotentMethod returns true if the provided HTTP method can be safely repeated
+func IsIdempotentMethod(method string) bool {
+	_, ok := idempotentMethods[method]
+	return ok
+}
+
+// errorStatusCode returns the status code of the first error in the chain that provides one, if any
+func errorStatusCode(err error) (int, bool) {
+	var cerr coder
+	if errors.As(err, &cerr) {
+		return cerr.Code(), true
+	}
+
+	var serr statusCoder
+	if errors.As(err, &serr) {
+		if eserr, ok := serr.(explicitStatusCoder); ok && !eserr.HasStatusCode() {
+			return 0, false
+		}
+		return serr.StatusCode(), true
+	}
+
+	return 0, false
+}
+
+// isNetworkFailure returns true if the error was caused by a network timeout or a dropped connection
+func isNetworkFailure(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.ECONNABORTED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
@@ -0,0 +1,120 @@
+package errors
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"syscall"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testTimeoutError struct{}
+
+func (e testTimeoutError) Error() string   { return "i/o timeout" }
+func (e testTimeoutError) Timeout() bool   { return true }
+func (e testTimeoutError) Temporary() bool { return true }
+
+type testStatusCodeError struct {
+	statusCode int
+}
+
+func (e *testStatusCodeError) Error() string   { return fmt.Sprintf("%d: graphql error", e.statusCode) }
+func (e *testStatusCodeError) StatusCode() int { return e.statusCode }
+
+func TestIsTemporary(t *testing.T) {
+	Convey("Given errors caused by temporary network failures, then they are temporary and retryable", t, func() {
+		for _, err := range []error{
+			&url.Error{Op: "Get", URL: "http://localhost:22000", Err: testTimeoutError{}},
+			&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET},
+			&net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED},
+			fmt.Errorf("failed to read response: %w", io.ErrUnexpectedEOF),
+			context.DeadlineExceeded,
+		} {
+			So(IsTemporary(err), ShouldBeTrue)
+			So(IsRetryable(err), ShouldBeTrue)
+		}
+	})
+
+	Convey("Given network failures wrapped in errors with a status code that is not retryable, as the Cantabular client does, then they are temporary and retryable", t, func() {
+		for _, err := range []error{
+			New(fmt.Errorf("failed to make request: %w", &url.Error{Op: "Post", URL: "http://localhost:8491/graphql", Err: testTimeoutError{}}), http.StatusInternalServerError, nil),
+			New(fmt.Errorf("failed to make request: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), http.StatusInternalServerError, nil),
+			New(&net.OpError{Op: "read", Net: "tcp", Err: syscall.ECONNRESET}, 0, nil),
+		} {
+			So(IsTemporary(err), ShouldBeTrue)
+			So(IsRetryable(err), ShouldBeTrue)
+		}
+	})
+
+	Convey("Given errors with temporary failure status codes, then they are temporary", t, func() {
+		for _, code := range []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout} {
+			So(IsTemporary(New(errors.New("downstream failure"), code, nil)), ShouldBeTrue)
+			So(IsTemporary(fmt.Errorf("wrapped: %w", &testStatusCodeError{statusCode: code})), ShouldBeTrue)
+		}
+	})
+
+	Convey("Given errors with any other status code, then they are not temporary", t, func() {
+		for _, code := range []int{http.StatusBadRequest, http.StatusNotFound, http.StatusInternalServerError} {
+			So(IsTemporary(New(errors.New("downstream failure"), code, nil)), ShouldBeFalse)
+			So(IsTemporary(&testStatusCodeError{statusCode: code}), ShouldBeFalse)
+		}
+	})
+
+	Convey("Given a nil error or an error without a status code or network failure, then it is not temporary", t, func() {
+		So(IsTemporary(nil), ShouldBeFalse)
+		So(IsTemporary(errors.New("invalid dimension")), ShouldBeFalse)
+	})
+}
+
+func TestIsRetryable(t *testing.T) {
+	Convey("Given a cancelled operation, then it is not retryable", t, func() {
+		err := &url.Error{Op: "Get", URL: "http://localhost:22000", Err: context.Canceled}
+		So(IsRetryable(err), ShouldBeFalse)
+	})
+}
+
+func TestIsRetryableRequest(t *testing.T) {
+	Convey("Given a request that timed out", t, func() {
+		err := &url.Error{Op: "Post", URL: "http://localhost:22000", Err: testTimeoutError{}}
+
+		Convey("Then it is safe to retry for idempotent methods only", func() {
+			So(IsRetryableRequest(http.MethodGet, err), ShouldBeTrue)
+			So(IsRetryableRequest(http.MethodPut, err), ShouldBeTrue)
+			So(IsRetryableRequest(http.MethodPost, err), ShouldBeFalse)
+			So(IsRetryableRequest(http.MethodPatch, err), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given requests that were not processed, then they are safe to retry for any method", t, func() {
+		So(IsRetryableRequest(http.MethodPost, New(errors.New("rate limited"), http.StatusTooManyRequests, nil)), ShouldBeTrue)
+		So(IsRetryableRequest(http.MethodPost, New(errors.New("unavailable"), http.StatusServiceUnavailable, nil)), ShouldBeTrue)
+		So(IsRetryableRequest(http.MethodPost, &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), ShouldBeTrue)
+	})
+
+	Convey("Given a refused connection wrapped in an error with a 500 status code, then it is safe to retry for any method", t, func() {
+		err := New(fmt.Errorf("failed to make request: %w", &net.OpError{Op: "dial", Net: "tcp", Err: syscall.ECONNREFUSED}), http.StatusInternalServerError, nil)
+		So(IsRetryableRequest(http.MethodPost, err), ShouldBeTrue)
+	})
+
+	Convey("Given a timeout wrapped in an error with a 500 status code, then it is only safe to retry for idempotent methods", t, func() {
+		err := New(fmt.Errorf("failed to make request: %w", &url.Error{Op: "Post", URL: "http://localhost:8491/graphql", Err: testTimeoutError{}}), http.StatusInternalServerError, nil)
+		So(IsRetryableRequest(http.MethodGet, err), ShouldBeTrue)
+		So(IsRetryableRequest(http.MethodPost, err), ShouldBeFalse)
+	})
+
+	Convey("Given a bad gateway response, then it is only safe to retry for idempotent methods", t, func() {
+		err := New(errors.New("bad gateway"), http.StatusBadGateway, nil)
+		So(IsRetryableRequest(http.MethodDelete, err), ShouldBeTrue)
+		So(IsRetryableRequest(http.MethodPost, err), ShouldBeFalse)
+	})
+
+	Convey("Given an error that is not retryable, then it is not safe to retry for any method", t, func() {
+		So(IsRetryableRequest(http.MethodGet, New(errors.New("not found"), http.StatusNotFound, nil)), ShouldBeFalse)
+	})
+}
@@ -60,8 +60,11 @@ func NewAPIClient(filesAPIURL, authToken string) *Client {
 
 // NewWithHealthClient creates a new instances of files Client using healthcheck client
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		hcCli: healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: hcClient,
 	}
 }
 
@@ -121,7 +124,7 @@ func (c *Client) GetFile(ctx context.Context, path string, authToken string) (Fi
 		err = json.NewDecoder(resp.Body).Decode(&metadata)
 		return metadata, err
 	case http.StatusNotFound:
-		return metadata, dperrors.FromBody(resp.Body)
+		return metadata, dperrors.FromResponse(resp)
 	}
 
 	return metadata, c.handleOtherCodes(resp)
@@ -240,7 +243,7 @@ func (c *Client) handleOtherCodes(resp *http.Response) error {
 	case http.StatusForbidden:
 		return ErrNotAuthorized
 	case http.StatusInternalServerError:
-		return fmt.Errorf("%w: %s", ErrServer, dperrors.FromBody(resp.Body))
+		return fmt.Errorf("%w: %w", ErrServer, dperrors.FromResponse(resp))
 	}
 
 	return fmt.Errorf("%w: %v", ErrUnexpectedStatus, resp.StatusCode)
@@ -438,7 +438,7 @@ func TestGetFile(t *testing.T) {
 				So(err.Error(), ShouldEqual, fmt.Sprintf("%s: %s: %s", files.ErrServer, expectedCode, expectedDescription))
 			})
 
-			Convey("invalid JSON error", func() {
+			Convey("plain text error", func() {
 				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 					w.WriteHeader(http.StatusNotFound)
 					fmt.Fprint(w, "<invalid JSON>")
@@ -451,7 +451,8 @@ func TestGetFile(t *testing.T) {
 				_, err := client.GetFile(context.Background(), filePath, "auth-token")
 
 				So(err, ShouldBeError)
-				So(err.Error(), ShouldContainSubstring, "invalid character")
+				So(err.Error(), ShouldEqual, "<invalid JSON>")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusNotFound)
 			})
 		})
 
@@ -539,7 +540,7 @@ func TestRegisterFile(t *testing.T) {
 				So(err.Error(), ShouldEqual, fmt.Sprintf("%s: %s: %s", files.ErrServer, expectedCode, expectedDescription))
 			})
 
-			Convey("with invalid JSON description", func() {
+			Convey("with plain text description", func() {
 				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 					w.WriteHeader(http.StatusInternalServerError)
 					fmt.Fprint(w, "<invalid JSON>")
@@ -551,7 +552,9 @@ func TestRegisterFile(t *testing.T) {
 				err := client.RegisterFile(context.Background(), files.FileMetaData{})
 
 				So(err, ShouldBeError)
-				So(err.Error(), ShouldContainSubstring, "invalid character")
+				So(errors.Is(err, files.ErrServer), ShouldBeTrue)
+				So(err.Error(), ShouldContainSubstring, "<invalid JSON>")
+				So(dperrors.StatusCode(err), ShouldEqual, http.StatusInternalServerError)
 			})
 		})
 
@@ -703,7 +706,7 @@ func TestPatchFile(t *testing.T) {
 				So(err.Error(), ShouldEqual, fmt.Sprintf("%s: %s: %s", files.ErrServer, expectedCode, expectedDescription))
 			})
 
-			Convey("with invalid JSON description", func() {
+			Convey("with plain text description", func() {
 				server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 					w.WriteHeader(http.StatusInternalServerError)
 					fmt.Fprint(w, "<invalid JSON>")
@@ -715,7 +718,8 @@ func TestPatchFile(t *testing.T) {
 				err := client.PatchFile(context.Background(), "a.txt", files.FilePatch{})
 
 				So(err, ShouldBeError)
-				So(err.Error(), ShouldContainSubstring, "invalid character")
+				So(errors.Is(err, files.ErrServer), ShouldBeTrue)
+				So(err.Error(), ShouldContainSubstring, "<invalid JSON>")
 			})
 		})
 
@@ -30,6 +30,7 @@ type ErrInvalidFilterAPIResponse struct {
 	ExpectedCode int
 	ActualCode   int
 	URI          string
+	response     dperrors.ResponseContext
 }
 
 // error definitions that are not related to invalid responses
@@ -62,6 +63,15 @@ func (e ErrInvalidFilterAPIResponse) Code() int {
 	return e.ActualCode
 }
 
+// LogData returns the details of the response received from filter api
+func (e ErrInvalidFilterAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["expected_code"] = e.ExpectedCode
+	logData["status_code"] = e.ActualCode
+	logData["uri"] = e.URI
+	return logData
+}
+
 var _ error = ErrInvalidFilterAPIResponse{}
 
 // Client is a filter api client which can be used to make requests to the server
@@ -93,8 +103,11 @@ func New(filterAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -191,7 +204,7 @@ func (c *Client) GetOutputBytes(ctx context.Context, userAuthToken, serviceAuthT
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return nil, err
 	}
 
@@ -239,7 +252,7 @@ func (c *Client) UpdateFilterOutputBytes(ctx context.Context, userAuthToken, ser
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 	return nil
 }
@@ -281,7 +294,7 @@ func (c *Client) AddEvent(ctx context.Context, userAuthToken, serviceAuthToken,
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 	return nil
 }
@@ -312,7 +325,7 @@ func (c *Client) GetDimensionBytes(ctx context.Context, userAuthToken, serviceAu
 
 	if resp.StatusCode != http.StatusOK {
 		if resp.StatusCode != http.StatusNoContent {
-			err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+			err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		}
 		return nil, "", err
 	}
@@ -359,7 +372,7 @@ func (c *Client) GetDimensionsBytes(ctx context.Context, userAuthToken, serviceA
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return nil, "", err
 	}
 
@@ -405,7 +418,7 @@ func (c *Client) GetDimensionOptionsBytes(ctx context.Context, userAuthToken, se
 
 	if resp.StatusCode != http.StatusOK {
 		if resp.StatusCode != http.StatusNoContent {
-			err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+			err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		}
 		return nil, "", err
 	}
@@ -498,7 +511,7 @@ func (c *Client) DeleteDimensionOptions(ctx context.Context, userAuthToken, serv
 
 	if res.StatusCode != http.StatusNoContent {
 		return "", dperrors.New(
-			errors.Wrap(&ErrInvalidFilterAPIResponse{http.StatusNoContent, res.StatusCode, uri}, "unexpected response"),
+			errors.Wrap(&ErrInvalidFilterAPIResponse{http.StatusNoContent, res.StatusCode, uri, dperrors.NewResponseContext(res)}, "unexpected response"),
 			res.StatusCode,
 			logData,
 		)
@@ -659,7 +672,7 @@ func (c *Client) CreateCustomFilter(ctx context.Context, userAuthToken, serviceA
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: resp.StatusCode, URI: uri}
+		return "", ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: resp.StatusCode, URI: uri, response: dperrors.NewResponseContext(resp)}
 	}
 
 	respBody, err := ioutil.ReadAll(resp.Body)
@@ -711,7 +724,7 @@ func (c *Client) postBlueprint(ctx context.Context, userAuthToken, serviceAuthTo
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusCreated {
-		return nil, "", ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+		return nil, "", ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	eTag, err := headers.GetResponseETag(resp)
@@ -769,7 +782,7 @@ func (c *Client) UpdateBlueprint(ctx context.Context, userAuthToken, serviceAuth
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return m, "", ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return m, "", ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	eTag, err := headers.GetResponseETag(resp)
@@ -899,7 +912,7 @@ func (c *Client) UpdateFlexBlueprint(ctx context.Context, userAuthToken, service
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return m, "", ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return m, "", ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	eTag, err := headers.GetResponseETag(resp)
@@ -955,7 +968,7 @@ func (c *Client) AddDimensionValue(ctx context.Context, userAuthToken, serviceAu
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+		return "", &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -971,6 +984,61 @@ func (c *Client) AddDimensionValues(ctx context.Context, userAuthToken, serviceA
 	return c.PatchDimensionValues(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, values, []string{}, batchSize, ifMatch)
 }
 
+// AddDimensionValuesConcurrently adds the provided values to a dimension option list with PATCH operations in batches of size up to batchSize,
+// sending up to maxWorkers batches concurrently. As the batches may be sent in any order, they are not validated against any eTag.
+// All batches are sent regardless of failures, and the returned report lists the ones that failed, so that only their values need to be retried.
+func (c *Client) AddDimensionValuesConcurrently(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, values []string, batchSize, maxWorkers int) (*batch.Report, error) {
+	uri := fmt.Sprintf("%s/filters/%s/dimensions/%s", c.hcCli.URL, filterID, name)
+
+	clientlog.Do(ctx, "attempting to add dimension options concurrently in batches", service, uri, log.Data{
+		"method":         http.MethodPatch,
+		"collection_id":  collectionID,
+		"filter_id":      filterID,
+		"dimension_name": name,
+		"batch_size":     batchSize,
+		"max_workers":    maxWorkers,
+		"num_add_values": len(values),
+	})
+
+	// func to perform an 'add' PATCH operation for a batch, without eTag validation
+	processAddPatch := func(items []string) error {
+		patchBody := []dprequest.Patch{
+			{
+				Op:    dprequest.OpAdd.String(),
+				Path:  "/options/-",
+				Value: items,
+			},
+		}
+		resp, err := c.doPatchWithAuthHeaders(ctx, userAuthToken, serviceAuthToken, collectionID, uri, headers.IfMatchAnyETag, patchBody)
+		if err != nil {
+			return err
+		}
+		defer closeResponseBody(ctx, resp)
+
+		if resp.StatusCode != http.StatusOK {
+			return &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
+		}
+		return nil
+	}
+
+	report, err := batch.ProcessInBatchesConcurrently(values, processAddPatch, batchSize, maxWorkers, true)
+	if report == nil {
+		return nil, err
+	}
+
+	logData := log.Data{
+		"num_successful_batches_added": len(report.Succeeded),
+		"num_failed_batches":           len(report.Failed),
+	}
+	if err != nil {
+		log.Error(ctx, "error sending concurrent PATCH operations in batches", err, logData)
+		return report, err
+	}
+
+	log.Info(ctx, "successfully sent concurrent PATCH operations in batches", logData)
+	return report, nil
+}
+
 // RemoveDimensionValues removes the provided values from a dimension option list. This is performed with PATCH operations in batches of size up to batchSize.
 func (c *Client) RemoveDimensionValues(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string, values []string, batchSize int, ifMatch string) (latestETag string, err error) {
 	return c.PatchDimensionValues(ctx, userAuthToken, serviceAuthToken, collectionID, filterID, name, []string{}, values, batchSize, ifMatch)
@@ -1003,7 +1071,7 @@ func (c *Client) PatchDimensionValues(ctx context.Context, userAuthToken, servic
 
 		// check response code
 		if resp.StatusCode != http.StatusOK {
-			return &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+			return &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		}
 
 		// get eTag from response
@@ -1139,7 +1207,7 @@ func (c *Client) UpdateDimensions(ctx context.Context, userAuthToken, serviceAut
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return dimension, "", err
 	}
 
@@ -1196,7 +1264,7 @@ func (c *Client) RemoveDimensionValue(ctx context.Context, userAuthToken, servic
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusNoContent {
-		return "", &ErrInvalidFilterAPIResponse{http.StatusNoContent, resp.StatusCode, uri}
+		return "", &ErrInvalidFilterAPIResponse{http.StatusNoContent, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -1242,7 +1310,7 @@ func (c *Client) RemoveDimension(ctx context.Context, userAuthToken, serviceAuth
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusNoContent {
-		err = &ErrInvalidFilterAPIResponse{http.StatusNoContent, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusNoContent, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return "", err
 	}
 
@@ -1288,7 +1356,7 @@ func (c *Client) AddDimension(ctx context.Context, userAuthToken, serviceAuthTok
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusCreated {
-		err = &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return "", err
 	}
 
@@ -1351,7 +1419,7 @@ func (c *Client) AddFlexDimension(ctx context.Context, userAuthToken, serviceAut
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusCreated {
-		err = &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return "", err
 	}
 
@@ -1387,7 +1455,7 @@ func (c *Client) GetJobStateBytes(ctx context.Context, userAuthToken, serviceAut
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		err = &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 		return nil, "", err
 	}
 
@@ -1446,7 +1514,7 @@ func (c *Client) SetDimensionValues(ctx context.Context, userAuthToken, serviceA
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri}
+		return "", &ErrInvalidFilterAPIResponse{http.StatusCreated, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	eTag, err = headers.GetResponseETag(resp)
@@ -1484,7 +1552,7 @@ func (c *Client) GetPreviewBytes(ctx context.Context, userAuthToken, serviceAuth
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return nil, &ErrInvalidFilterAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	return ioutil.ReadAll(resp.Body)
@@ -538,10 +538,20 @@ func TestClient_GetDimensionOptions(t *testing.T) {
 
 		Convey("then GetDimensionOptions returns the expected error", func() {
 			_, _, err := mockedAPI.GetDimensionOptions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterOutputID, name, nil)
+			uri := fmt.Sprintf("%s/filters/%s/dimensions/%s/options", mockedAPI.hcCli.URL, filterOutputID, name)
+
+			So(err, ShouldHaveSameTypeAs, &ErrInvalidFilterAPIResponse{})
+			errResponse := err.(*ErrInvalidFilterAPIResponse).response
+			So(errResponse.Method, ShouldEqual, http.MethodGet)
+			So(errResponse.URL, ShouldEqual, uri)
+			So(errResponse.RequestID, ShouldNotBeEmpty)
+			So(errResponse.BodySnippet, ShouldEqual, "")
+
+			err.(*ErrInvalidFilterAPIResponse).response = dperrors.ResponseContext{}
 			So(err, ShouldResemble, &ErrInvalidFilterAPIResponse{
 				ActualCode:   400,
 				ExpectedCode: 200,
-				URI:          fmt.Sprintf("%s/filters/%s/dimensions/%s/options", mockedAPI.hcCli.URL, filterOutputID, name),
+				URI:          uri,
 			})
 		})
 	})
@@ -555,10 +565,21 @@ func TestClient_GetDimensionOptions(t *testing.T) {
 
 		Convey("then GetDimensionOptions returns the expected error", func() {
 			_, _, err := mockedAPI.GetDimensionOptions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterOutputID, name, nil)
+			uri := fmt.Sprintf("%s/filters/%s/dimensions/%s/options", mockedAPI.hcCli.URL, filterOutputID, name)
+
+			So(err, ShouldHaveSameTypeAs, &ErrInvalidFilterAPIResponse{})
+			errResponse := err.(*ErrInvalidFilterAPIResponse).response
+			So(errResponse.Method, ShouldEqual, http.MethodGet)
+			So(errResponse.URL, ShouldEqual, uri)
+			So(errResponse.RequestID, ShouldNotBeEmpty)
+			So(errResponse.BodySnippet, ShouldEqual, "qux")
+			So(dperrors.LogData(err)["response_body"], ShouldEqual, "qux")
+
+			err.(*ErrInvalidFilterAPIResponse).response = dperrors.ResponseContext{}
 			So(err, ShouldResemble, &ErrInvalidFilterAPIResponse{
 				ActualCode:   500,
 				ExpectedCode: 200,
-				URI:          fmt.Sprintf("%s/filters/%s/dimensions/%s/options", mockedAPI.hcCli.URL, filterOutputID, name),
+				URI:          uri,
 			})
 		})
 	})
@@ -907,7 +928,7 @@ func TestClient_DeleteDimensionOptions(t *testing.T) {
 			eTag, err := filterClient.DeleteDimensionOptions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name)
 
 			Convey("Then an error is returned with no ETag", func() {
-				expectedErr := errors.Wrap(&ErrInvalidFilterAPIResponse{http.StatusNoContent, http.StatusNotFound, "http://localhost:8080/filters/foo/dimensions/corge/options"}, "unexpected response")
+				expectedErr := errors.Wrap(&ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusNoContent, ActualCode: http.StatusNotFound, URI: "http://localhost:8080/filters/foo/dimensions/corge/options"}, "unexpected response")
 				So(err.Error(), ShouldResemble, expectedErr.Error())
 				So(eTag, ShouldResemble, "")
 			})
@@ -930,7 +951,7 @@ func TestClient_DeleteDimensionOptions(t *testing.T) {
 			eTag, err := filterClient.DeleteDimensionOptions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name)
 
 			Convey("Then an error is returned with no ETag", func() {
-				expectedErr := errors.Wrap(&ErrInvalidFilterAPIResponse{http.StatusNoContent, http.StatusBadRequest, "http://localhost:8080/filters/foo/dimensions/corge/options"}, "unexpected response")
+				expectedErr := errors.Wrap(&ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusNoContent, ActualCode: http.StatusBadRequest, URI: "http://localhost:8080/filters/foo/dimensions/corge/options"}, "unexpected response")
 				So(err.Error(), ShouldResemble, expectedErr.Error())
 				So(eTag, ShouldResemble, "")
 			})
@@ -953,7 +974,7 @@ func TestClient_DeleteDimensionOptions(t *testing.T) {
 			eTag, err := filterClient.DeleteDimensionOptions(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name)
 
 			Convey("Then an error is returned with no ETag", func() {
-				expectedErr := errors.Wrap(&ErrInvalidFilterAPIResponse{http.StatusNoContent, http.StatusConflict, "http://localhost:8080/filters/foo/dimensions/corge/options"}, "unexpected response")
+				expectedErr := errors.Wrap(&ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusNoContent, ActualCode: http.StatusConflict, URI: "http://localhost:8080/filters/foo/dimensions/corge/options"}, "unexpected response")
 				So(err.Error(), ShouldResemble, expectedErr.Error())
 				So(eTag, ShouldResemble, "")
 			})
@@ -1076,7 +1097,7 @@ func TestClient_CreateFlexBlueprint(t *testing.T) {
 
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusCreated, 500, url + "/filters"}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: 500, URI: url + "/filters"}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -1231,7 +1252,7 @@ func TestClient_CreateFlexBlueprintCustom(t *testing.T) {
 
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusCreated, 500, url + "/filters"}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: 500, URI: url + "/filters"}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -1334,7 +1355,7 @@ func TestClient_CreateBlueprint(t *testing.T) {
 
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusCreated, 500, url + "/filters"}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: 500, URI: url + "/filters"}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -1570,7 +1591,7 @@ func TestClient_UpdateBlueprint(t *testing.T) {
 
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusOK, 500, url + "/filters/?submitted=" + strconv.FormatBool(doSubmit)}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusOK, ActualCode: 500, URI: url + "/filters/?submitted=" + strconv.FormatBool(doSubmit)}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -1693,7 +1714,7 @@ func TestClient_UpdateFlexBlueprint(t *testing.T) {
 
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusOK, 500, url + "/filters/?submitted=" + strconv.FormatBool(doSubmit)}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusOK, ActualCode: 500, URI: url + "/filters/?submitted=" + strconv.FormatBool(doSubmit)}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -1770,7 +1791,7 @@ func TestClient_AddDimensionValue(t *testing.T) {
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
 		uri := url + "/filters/" + filterID + "/dimensions/" + name + "/options/filter-api"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusCreated, 500, uri}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: 500, URI: uri}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -1843,7 +1864,7 @@ func TestClient_RemoveDimensionValue(t *testing.T) {
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
 		uri := url + "/filters/" + filterID + "/dimensions/" + name + "/options/filter-api"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusNoContent, 500, uri}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusNoContent, ActualCode: 500, URI: uri}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -2162,6 +2183,50 @@ func TestClient_AddDimensionValues(t *testing.T) {
 	})
 }
 
+func TestClient_AddDimensionValuesConcurrently(t *testing.T) {
+	filterID := "baz"
+	name := "quz"
+	batchSize := 2
+	maxWorkers := 2
+
+	Convey("Given a filter API that fails to add the options of one of the batches", t, func() {
+		httpClient := newMockHTTPClient(nil, nil)
+		httpClient.DoFunc = func(ctx context.Context, req *http.Request) (*http.Response, error) {
+			b, err := ioutil.ReadAll(req.Body)
+			if err != nil {
+				return nil, err
+			}
+			if strings.Contains(string(b), "ghi") {
+				return &http.Response{StatusCode: http.StatusInternalServerError, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader(""))}, nil
+		}
+
+		filterClient := newFilterClient(httpClient)
+
+		Convey("when AddDimensionValuesConcurrently is called", func() {
+			options := []string{"abc", "def", "ghi", "jkl", "000"}
+			report, err := filterClient.AddDimensionValuesConcurrently(ctx, testUserAuthToken, testServiceToken, testCollectionID, filterID, name, options, batchSize, maxWorkers)
+
+			Convey("then all the batches are sent without eTag validation", func() {
+				So(len(httpClient.DoCalls()), ShouldEqual, 3)
+				for i := range httpClient.DoCalls() {
+					checkRequest(httpClient, i, http.MethodPatch, "/filters/"+filterID+"/dimensions/"+name, "*")
+				}
+			})
+
+			Convey("then the error of the failed batch is returned, along with a report of the failed and successful batches", func() {
+				So(err, ShouldNotBeNil)
+				So(report.Succeeded, ShouldHaveLength, 2)
+				So(report.Failed, ShouldHaveLength, 1)
+				So(report.Failed[0].Index, ShouldEqual, 1)
+				So(report.Failed[0].Err, ShouldHaveSameTypeAs, &ErrInvalidFilterAPIResponse{})
+				So(report.PendingItems(), ShouldResemble, []string{"ghi", "jkl"})
+			})
+		})
+	})
+}
+
 func TestClient_RemoveDimensionValues(t *testing.T) {
 	filterID := "baz"
 	name := "quz"
@@ -2457,7 +2522,7 @@ func TestClient_UpdateDimensions(t *testing.T) {
 
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
-		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{http.StatusOK, 500, fmt.Sprintf("%s/filters/%s/dimensions/%s", url, testID, testName)}
+		mockInvalidStatusCodeError := ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusOK, ActualCode: 500, URI: fmt.Sprintf("%s/filters/%s/dimensions/%s", url, testID, testName)}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -2686,7 +2751,7 @@ func TestClient_SetDimensionValues(t *testing.T) {
 	Convey("given dphttpclient.do returns a non 200 response status", t, func() {
 		url := "http://localhost:8080"
 		uri := url + "/filters/" + filterID + "/dimensions/" + name
-		mockInvalidStatusCodeError := &ErrInvalidFilterAPIResponse{http.StatusCreated, http.StatusInternalServerError, uri}
+		mockInvalidStatusCodeError := &ErrInvalidFilterAPIResponse{ExpectedCode: http.StatusCreated, ActualCode: http.StatusInternalServerError, URI: uri}
 		httpClient := newMockHTTPClient(&http.Response{
 			StatusCode: http.StatusInternalServerError,
 			Body:       ioutil.NopCloser(bytes.NewReader([]byte(""))),
@@ -36,8 +36,11 @@ func New(cfg Config) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(cfg Config, cli *health.Client) *Client {
+	hcClient := health.NewClientWithClienter(service, cli.URL, cli.Client)
+	hcClient.LatencyThresholds = cli.LatencyThresholds
+
 	return &Client{
-		health: health.NewClientWithClienter(service, cli.URL, cli.Client),
+		health: hcClient,
 		cfg:    cfg,
 	}
 }
@@ -0,0 +1,80 @@
+package headers
+
+import (
+	"net/http"
+)
+
+// AuthBundle holds all the authentication and request context values that clients need to forward
+// from an inbound request to the outbound requests made on its behalf
+type AuthBundle struct {
+	UserAuthToken        string
+	ServiceAuthToken     string
+	DownloadServiceToken string
+	CollectionID         string
+	IDToken              string
+	RefreshToken         string
+	RequestID            string
+	LocaleCode           string
+}
+
+// GetAuthBundle returns an AuthBundle with the values found in the provided request. Missing values are left empty.
+// The user auth token is read from the "X-Florence-Token" header, or from the florence "access_token" cookie if the
+// header is not present. Returns ErrRequestNil if the request is nil.
+func GetAuthBundle(req *http.Request) (AuthBundle, error) {
+	if req == nil {
+		return AuthBundle{}, ErrRequestNil
+	}
+
+	bundle := AuthBundle{
+		UserAuthToken:        valueOrEmpty(GetUserAuthToken(req)),
+		ServiceAuthToken:     valueOrEmpty(GetServiceAuthToken(req)),
+		DownloadServiceToken: valueOrEmpty(GetDownloadServiceToken(req)),
+		CollectionID:         valueOrEmpty(GetCollectionID(req)),
+		IDToken:              valueOrEmpty(GetIDTokenHeader(req)),
+		RefreshToken:         valueOrEmpty(GetRefreshTokenHeader(req)),
+		RequestID:            valueOrEmpty(GetRequestID(req)),
+		LocaleCode:           valueOrEmpty(GetLocaleCode(req)),
+	}
+
+	if len(bundle.UserAuthToken) == 0 {
+		bundle.UserAuthToken = valueOrEmpty(GetFlorenceCookie(req))
+	}
+
+	return bundle, nil
+}
+
+// Apply sets all the non-empty values of the AuthBundle as headers on the provided request, overwriting any existing
+// values. The service auth token is set after the user auth token, so it takes precedence in the "Authorization" header.
+// Returns ErrRequestNil if the request is nil.
+func (b AuthBundle) Apply(req *http.Request) error {
+	if req == nil {
+		return ErrRequestNil
+	}
+
+	setters := []func() error{
+		func() error { return SetAuthToken(req, b.UserAuthToken) },
+		func() error { return SetServiceAuthToken(req, b.ServiceAuthToken) },
+		func() error { return SetDownloadServiceToken(req, b.DownloadServiceToken) },
+		func() error { return SetCollectionID(req, b.CollectionID) },
+		func() error { return SetIDTokenHeader(req, b.IDToken) },
+		func() error { return SetRefreshTokenHeader(req, b.RefreshToken) },
+		func() error { return SetRequestID(req, b.RequestID) },
+		func() error { return SetLocaleCode(req, b.LocaleCode) },
+	}
+
+	for _, set := range setters {
+		if err := set(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// valueOrEmpty returns the provided value, or an empty string if an error occurred getting it
+func valueOrEmpty(value string, err error) string {
+	if err != nil {
+		return ""
+	}
+	return value
+}
@@ -0,0 +1,111 @@
+package headers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAuthBundle(t *testing.T) {
+	Convey("Given a request with all the supported headers", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(userAuthTokenHeader, "user-token")
+		req.Header.Set(serviceAuthTokenHeader, bearerPrefix+"service-token")
+		req.Header.Set(downloadServiceTokenHeader, "download-token")
+		req.Header.Set(collectionIDHeader, "collection-id")
+		req.Header.Set(idTokenHeader, "id-token")
+		req.Header.Set(refreshTokenHeader, "refresh-token")
+		req.Header.Set(requestIDHeader, "request-id")
+		req.Header.Set(localeCodeHeader, "cy")
+		req.AddCookie(&http.Cookie{Name: florenceCookieKey, Value: "cookie-token"})
+
+		Convey("When GetAuthBundle is called", func() {
+			bundle, err := GetAuthBundle(req)
+
+			Convey("Then all the values are captured, with the header user token taking precedence over the cookie", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, AuthBundle{
+					UserAuthToken:        "user-token",
+					ServiceAuthToken:     "service-token",
+					DownloadServiceToken: "download-token",
+					CollectionID:         "collection-id",
+					IDToken:              "id-token",
+					RefreshToken:         "refresh-token",
+					RequestID:            "request-id",
+					LocaleCode:           "cy",
+				})
+			})
+		})
+	})
+
+	Convey("Given a request with the user token in the florence cookie only", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: florenceCookieKey, Value: "cookie-token"})
+
+		Convey("When GetAuthBundle is called", func() {
+			bundle, err := GetAuthBundle(req)
+
+			Convey("Then the user token is read from the cookie and missing values are left empty", func() {
+				So(err, ShouldBeNil)
+				So(bundle, ShouldResemble, AuthBundle{UserAuthToken: "cookie-token"})
+			})
+		})
+	})
+
+	Convey("Given a nil request", t, func() {
+		Convey("When GetAuthBundle is called", func() {
+			_, err := GetAuthBundle(nil)
+
+			Convey("Then the expected error is returned", func() {
+				So(err, ShouldEqual, ErrRequestNil)
+			})
+		})
+	})
+}
+
+func TestAuthBundle_Apply(t *testing.T) {
+	Convey("Given an AuthBundle with user and service tokens and some empty values", t, func() {
+		bundle := AuthBundle{
+			UserAuthToken:    "user-token",
+			ServiceAuthToken: "service-token",
+			CollectionID:     "collection-id",
+			RequestID:        "request-id",
+		}
+
+		Convey("When it is applied to an outbound request", func() {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			err := bundle.Apply(req)
+
+			Convey("Then the non-empty values are set as headers", func() {
+				So(err, ShouldBeNil)
+				So(req.Header.Get(userAuthTokenHeader), ShouldEqual, "user-token")
+				So(req.Header.Get(serviceAuthTokenHeader), ShouldEqual, bearerPrefix+"service-token")
+				So(req.Header.Get(collectionIDHeader), ShouldEqual, "collection-id")
+				So(req.Header.Get(requestIDHeader), ShouldEqual, "request-id")
+			})
+
+			Convey("Then the empty values are not set", func() {
+				So(req.Header, ShouldNotContainKey, downloadServiceTokenHeader)
+				So(req.Header, ShouldNotContainKey, idTokenHeader)
+				So(req.Header, ShouldNotContainKey, refreshTokenHeader)
+				So(req.Header, ShouldNotContainKey, localeCodeHeader)
+			})
+
+			Convey("Then the bundle read back from the request matches the original", func() {
+				copied, err := GetAuthBundle(req)
+				So(err, ShouldBeNil)
+				So(copied, ShouldResemble, bundle)
+			})
+		})
+
+		Convey("When it is applied to a nil request", func() {
+			err := bundle.Apply(nil)
+
+			Convey("Then the expected error is returned", func() {
+				So(err, ShouldEqual, ErrRequestNil)
+			})
+		})
+	})
+}
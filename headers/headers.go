@@ -50,6 +50,9 @@ const (
 
 	// eTagHeader is the ETag header name
 	eTagHeader = "ETag"
+
+	// florenceCookieKey is the name of the cookie holding the florence (user) access token
+	florenceCookieKey = "access_token"
 )
 
 const (
@@ -119,6 +122,33 @@ func GetUserIdentity(req *http.Request) (string, error) {
 	return getRequestHeader(req, userIdentityHeader)
 }
 
+// GetIDTokenHeader returns the value of the "ID" request header if it exists, returns
+// ErrHeaderNotFound if the header is not found.
+func GetIDTokenHeader(req *http.Request) (string, error) {
+	return getRequestHeader(req, idTokenHeader)
+}
+
+// GetRefreshTokenHeader returns the value of the "Refresh" request header if it exists, returns
+// ErrHeaderNotFound if the header is not found.
+func GetRefreshTokenHeader(req *http.Request) (string, error) {
+	return getRequestHeader(req, refreshTokenHeader)
+}
+
+// GetFlorenceCookie returns the value of the florence "access_token" cookie if it exists, returns
+// ErrHeaderNotFound if the cookie is not found.
+func GetFlorenceCookie(req *http.Request) (string, error) {
+	if req == nil {
+		return "", ErrRequestNil
+	}
+
+	cookie, err := req.Cookie(florenceCookieKey)
+	if err != nil || len(cookie.Value) == 0 {
+		return "", ErrHeaderNotFound
+	}
+
+	return cookie.Value, nil
+}
+
 // GetRequestID returns the value of the "X-Request-Id" request header if it exists, returns
 // ErrHeaderNotFound if the header is not found.
 func GetRequestID(req *http.Request) (string, error) {
@@ -131,6 +161,12 @@ func GetLocaleCode(req *http.Request) (string, error) {
 	return getRequestHeader(req, localeCodeHeader)
 }
 
+// GetAcceptedLang returns the value of the "Accept-Language" request header if it exists, returns
+// ErrHeaderNotFound if the header is not found.
+func GetAcceptedLang(req *http.Request) (string, error) {
+	return getRequestHeader(req, acceptedLangHeader)
+}
+
 // GetIfMatch returns the value of the "If-Match" request header if it exists, returns
 // ErrHeaderNotFound if the header is not found.
 func GetIfMatch(req *http.Request) (string, error) {
@@ -283,6 +319,8 @@ func SetLocaleCode(req *http.Request, headerValue string) error {
 	return nil
 }
 
+// SetAcceptedLang set the Accept-Language header on the provided request. If this header is already present it
+// will be overwritten by the new value. Empty values are allowed for this header.
 func SetAcceptedLang(req *http.Request, headerValue string) error {
 	err := setRequestHeader(req, acceptedLangHeader, headerValue)
 	if err != nil && err != ErrValueEmpty {
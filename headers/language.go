@@ -0,0 +1,160 @@
+package headers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Locales supported by the ONS website
+const (
+	LocaleEnglish = "en"
+	LocaleWelsh   = "cy"
+
+	// DefaultLocale is the locale used when none of the preferred languages is supported
+	DefaultLocale = LocaleEnglish
+)
+
+// SupportedLocales contains the locales supported by the ONS website, which are the valid values for the lang
+// parameter of clients such as zebedee.GetDatasetLandingPage, releasecalendar.GetLegacyRelease and articles.GetLegacyBulletin
+var SupportedLocales = []string{LocaleEnglish, LocaleWelsh}
+
+// LanguagePreference represents a language range of an Accept-Language header along with its quality value
+type LanguagePreference struct {
+	Tag     string
+	Quality float64
+}
+
+// ParseAcceptLanguage parses the value of an Accept-Language header into a list of language preferences, sorted by
+// descending quality value while preserving the order of equally preferred languages.
+// Invalid entries and languages with a quality value of 0 (i.e. not acceptable) are discarded.
+func ParseAcceptLanguage(value string) []LanguagePreference {
+	preferences := []LanguagePreference{}
+	for _, preference := range parseAcceptLanguage(value) {
+		if preference.Quality > 0 {
+			preferences = append(preferences, preference)
+		}
+	}
+	return preferences
+}
+
+// parseAcceptLanguage parses the value of an Accept-Language header into a list of language preferences, including
+// the languages with a quality value of 0, sorted by descending quality value. Invalid entries are discarded.
+func parseAcceptLanguage(value string) []LanguagePreference {
+	preferences := []LanguagePreference{}
+
+	for _, entry := range strings.Split(value, ",") {
+		parts := strings.Split(entry, ";")
+		tag := strings.TrimSpace(parts[0])
+		if len(tag) == 0 {
+			continue
+		}
+
+		quality := 1.0
+		valid := true
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if !strings.HasPrefix(param, "q=") {
+				continue
+			}
+			q, err := strconv.ParseFloat(strings.TrimPrefix(param, "q="), 64)
+			if err != nil || q < 0 || q > 1 {
+				valid = false
+				break
+			}
+			quality = q
+		}
+
+		if !valid {
+			continue
+		}
+
+		preferences = append(preferences, LanguagePreference{Tag: tag, Quality: quality})
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].Quality > preferences[j].Quality
+	})
+
+	return preferences
+}
+
+// NegotiateLocale returns the supported locale that best matches the provided Accept-Language header value.
+// A language range matches a supported locale if it is equal to it or if its primary subtag is equal to it (e.g. "cy-GB"
+// matches "cy"), and the wildcard "*" matches the first supported locale that is not refused with a quality value of 0
+// (e.g. "en;q=0, *" matches "cy"). If no SupportedLocales are provided, the SupportedLocales of the ONS website are used.
+// The first supported locale is returned if there is no match.
+func NegotiateLocale(acceptLanguage string, supported ...string) string {
+	if len(supported) == 0 {
+		supported = SupportedLocales
+	}
+
+	preferences := parseAcceptLanguage(acceptLanguage)
+	for _, preference := range preferences {
+		if preference.Quality == 0 {
+			break // preferences are sorted by quality, so the rest are refused
+		}
+		if preference.Tag == "*" {
+			if locale, ok := firstAcceptedLocale(preferences, supported); ok {
+				return locale
+			}
+			continue
+		}
+		if locale, ok := matchLocale(preference.Tag, supported); ok {
+			return locale
+		}
+	}
+
+	return supported[0]
+}
+
+// GetLangParam returns the locale to be provided as the lang parameter of client calls made on behalf of the provided
+// request. The "LocaleCode" header is used if it contains a supported locale, otherwise the locale is negotiated from
+// the "Accept-Language" header. DefaultLocale is returned if the request is nil or no supported locale is found.
+func GetLangParam(req *http.Request) string {
+	if req == nil {
+		return DefaultLocale
+	}
+
+	if localeCode, err := GetLocaleCode(req); err == nil {
+		if locale, ok := matchLocale(localeCode, SupportedLocales); ok {
+			return locale
+		}
+	}
+
+	acceptLanguage, err := GetAcceptedLang(req)
+	if err != nil {
+		return DefaultLocale
+	}
+
+	return NegotiateLocale(acceptLanguage, SupportedLocales...)
+}
+
+// firstAcceptedLocale returns the first supported locale that is not refused by a preference with a quality value of 0
+func firstAcceptedLocale(preferences []LanguagePreference, supported []string) (string, bool) {
+	for _, locale := range supported {
+		refused := false
+		for _, preference := range preferences {
+			if preference.Quality == 0 && strings.EqualFold(preference.Tag, locale) {
+				refused = true
+				break
+			}
+		}
+		if !refused {
+			return locale, true
+		}
+	}
+	return "", false
+}
+
+// matchLocale returns the supported locale matching the provided language tag, ignoring case
+func matchLocale(tag string, supported []string) (string, bool) {
+	primary := strings.SplitN(tag, "-", 2)[0]
+	for _, locale := range supported {
+		if strings.EqualFold(tag, locale) || strings.EqualFold(primary, locale) {
+			return locale, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,105 @@
+package headers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	Convey("Given an Accept-Language header value with quality values", t, func() {
+		value := "en-GB;q=0.8, cy-GB,cy;q=0.9 , fr;q=0, de;q=invalid,*;q=0.1"
+
+		Convey("When it is parsed", func() {
+			preferences := ParseAcceptLanguage(value)
+
+			Convey("Then the valid and acceptable languages are returned in order of preference", func() {
+				So(preferences, ShouldResemble, []LanguagePreference{
+					{Tag: "cy-GB", Quality: 1},
+					{Tag: "cy", Quality: 0.9},
+					{Tag: "en-GB", Quality: 0.8},
+					{Tag: "*", Quality: 0.1},
+				})
+			})
+		})
+	})
+
+	Convey("Given an empty Accept-Language header value", t, func() {
+		Convey("When it is parsed, then no preferences are returned", func() {
+			So(ParseAcceptLanguage(""), ShouldBeEmpty)
+		})
+	})
+}
+
+func TestNegotiateLocale(t *testing.T) {
+	Convey("Given a Welsh browser preference", t, func() {
+		Convey("Then the Welsh locale is negotiated", func() {
+			So(NegotiateLocale("cy-GB,cy;q=0.9"), ShouldEqual, LocaleWelsh)
+			So(NegotiateLocale("fr-FR,CY;q=0.5,en;q=0.4"), ShouldEqual, LocaleWelsh)
+		})
+	})
+
+	Convey("Given an English browser preference with Welsh as a lower preference", t, func() {
+		Convey("Then the English locale is negotiated", func() {
+			So(NegotiateLocale("en-GB,en;q=0.9,cy;q=0.8"), ShouldEqual, LocaleEnglish)
+		})
+	})
+
+	Convey("Given a preference for unsupported languages only", t, func() {
+		Convey("Then the first supported locale is returned", func() {
+			So(NegotiateLocale("fr-FR,de;q=0.5"), ShouldEqual, LocaleEnglish)
+			So(NegotiateLocale("fr-FR", LocaleWelsh, LocaleEnglish), ShouldEqual, LocaleWelsh)
+		})
+	})
+
+	Convey("Given a wildcard preference", t, func() {
+		Convey("Then the first supported locale is returned", func() {
+			So(NegotiateLocale("fr-FR,*;q=0.5,cy;q=0.1"), ShouldEqual, LocaleEnglish)
+		})
+	})
+
+	Convey("Given a wildcard preference with a refused locale", t, func() {
+		Convey("Then the first supported locale that is not refused is returned", func() {
+			So(NegotiateLocale("en;q=0, *"), ShouldEqual, LocaleWelsh)
+			So(NegotiateLocale("*, EN;q=0"), ShouldEqual, LocaleWelsh)
+		})
+	})
+}
+
+func TestGetLangParam(t *testing.T) {
+	Convey("Given a request with a supported LocaleCode header and an Accept-Language header", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(localeCodeHeader, "cy")
+		req.Header.Set(acceptedLangHeader, "en-GB")
+
+		Convey("Then the LocaleCode header takes precedence", func() {
+			So(GetLangParam(req), ShouldEqual, LocaleWelsh)
+		})
+	})
+
+	Convey("Given a request with only an Accept-Language header", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(acceptedLangHeader, "cy-GB,cy;q=0.9")
+
+		Convey("Then the locale is negotiated from the Accept-Language header", func() {
+			So(GetLangParam(req), ShouldEqual, LocaleWelsh)
+		})
+	})
+
+	Convey("Given a request with an unsupported LocaleCode header and no Accept-Language header", t, func() {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(localeCodeHeader, "fr")
+
+		Convey("Then the default locale is returned", func() {
+			So(GetLangParam(req), ShouldEqual, DefaultLocale)
+		})
+	})
+
+	Convey("Given a nil request", t, func() {
+		Convey("Then the default locale is returned", func() {
+			So(GetLangParam(nil), ShouldEqual, DefaultLocale)
+		})
+	})
+}
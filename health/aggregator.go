@@ -0,0 +1,277 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Aggregator runs a set of health checkers concurrently, caching their results,
+// and produces a combined report of the health of all dependencies
+type Aggregator struct {
+	timeout  time.Duration
+	cacheTTL time.Duration
+	checks   []*aggregatedCheck
+	mutex    sync.RWMutex
+
+	lastReport *AggregateReport
+	reportLock sync.RWMutex
+	clock      clock
+}
+
+// AggregateReport represents the combined health of all the dependencies checked by an Aggregator
+type AggregateReport struct {
+	Status string             `json:"status"`
+	Checks []DependencyReport `json:"checks"`
+}
+
+// DependencyReport represents the health of a single dependency checked by an Aggregator
+type DependencyReport struct {
+	Name        string        `json:"name"`
+	Status      string        `json:"status"`
+	StatusCode  int           `json:"status_code,omitempty"`
+	Message     string        `json:"message"`
+	Latency     time.Duration `json:"-"`
+	LastChecked *time.Time    `json:"last_checked"`
+	LastSuccess *time.Time    `json:"last_success"`
+	LastFailure *time.Time    `json:"last_failure"`
+}
+
+// aggregatedCheck holds a checker along with the state and latency of its last run
+type aggregatedCheck struct {
+	name    string
+	checker health.Checker
+	timeout time.Duration
+	state   *health.CheckState
+	latency time.Duration
+	lastRun time.Time
+	mutex   sync.Mutex
+}
+
+// NewAggregator creates a new Aggregator.
+// timeout is the default maximum duration of each check, after which the dependency is considered critical.
+// cacheTTL is how long the result of a check is reused before the checker is called again.
+func NewAggregator(timeout, cacheTTL time.Duration) *Aggregator {
+	return &Aggregator{
+		timeout:  timeout,
+		cacheTTL: cacheTTL,
+	}
+}
+
+// AddCheck adds a named checker, using the default timeout of the Aggregator.
+// Any client Checker in this module (e.g. cantabular.Client.CheckerAPIExt) can be provided.
+func (a *Aggregator) AddCheck(name string, checker health.Checker) error {
+	return a.AddCheckWithTimeout(name, checker, a.timeout)
+}
+
+// AddCheckWithTimeout adds a named checker with its own timeout
+func (a *Aggregator) AddCheckWithTimeout(name string, checker health.Checker, timeout time.Duration) error {
+	if checker == nil {
+		return errors.New("expected checker but none provided")
+	}
+
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+
+	for _, check := range a.checks {
+		if check.name == name {
+			return fmt.Errorf("a check named %s has already been added", name)
+		}
+	}
+
+	a.checks = append(a.checks, &aggregatedCheck{
+		name:    name,
+		checker: checker,
+		timeout: timeout,
+		state:   health.NewCheckState(name),
+	})
+	return nil
+}
+
+// Check runs all the checks concurrently, reusing cached results that are still valid,
+// and returns the combined report. Checks are reported in the order they were added.
+// The checks are not cancelled along with the provided context (e.g. when the client of a health request disconnects),
+// so that their cached results always reflect the health of the dependencies. They are only limited by their timeout.
+func (a *Aggregator) Check(ctx context.Context) *AggregateReport {
+	a.mutex.RLock()
+	checks := make([]*aggregatedCheck, len(a.checks))
+	copy(checks, a.checks)
+	a.mutex.RUnlock()
+
+	report := &AggregateReport{
+		Status: health.StatusOK,
+		Checks: make([]DependencyReport, len(checks)),
+	}
+
+	wg := &sync.WaitGroup{}
+	for i, check := range checks {
+		wg.Add(1)
+		go func(i int, check *aggregatedCheck) {
+			defer wg.Done()
+			report.Checks[i] = check.run(ctx, a.cacheTTL, a.clock)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, check := range report.Checks {
+		report.Status = worstStatus(report.Status, check.Status)
+	}
+
+	a.reportLock.Lock()
+	a.lastReport = report
+	a.reportLock.Unlock()
+
+	return report
+}
+
+// LastReport returns the report produced by the most recent call to Check,
+// or nil if the checks have not been run yet
+func (a *Aggregator) LastReport() *AggregateReport {
+	a.reportLock.RLock()
+	defer a.reportLock.RUnlock()
+
+	return a.lastReport
+}
+
+// Start runs the checks immediately and then at the provided interval in a separate go-routine,
+// so that LastReport is kept up to date, until the provided context is done
+func (a *Aggregator) Start(ctx context.Context, interval time.Duration) {
+	a.Check(ctx)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				a.Check(ctx)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+}
+
+// Dependency returns the report of the named dependency, if it is present
+func (r *AggregateReport) Dependency(name string) (DependencyReport, bool) {
+	for _, check := range r.Checks {
+		if check.Name == name {
+			return check, true
+		}
+	}
+	return DependencyReport{}, false
+}
+
+// ServeHTTP runs the checks and responds with the combined report, so that the Aggregator
+// can be served directly, e.g. by providing its ServeHTTP method to middleware.HealthcheckFilter
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	ctx := req.Context()
+	report := a.Check(ctx)
+
+	b, err := json.Marshal(report)
+	if err != nil {
+		log.Error(ctx, "failed to marshal aggregate health report", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+
+	switch report.Status {
+	case health.StatusOK:
+		w.WriteHeader(http.StatusOK)
+	case health.StatusWarning:
+		w.WriteHeader(http.StatusTooManyRequests)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+
+	if _, err = w.Write(b); err != nil {
+		log.Error(ctx, "failed to write aggregate health report", err)
+	}
+}
+
+// MarshalJSON returns the json representation of the dependency report, with the latency in milliseconds
+func (d DependencyReport) MarshalJSON() ([]byte, error) {
+	type dependencyReport DependencyReport
+	return json.Marshal(struct {
+		dependencyReport
+		LatencyMillis int64 `json:"latency_ms"`
+	}{
+		dependencyReport: dependencyReport(d),
+		LatencyMillis:    d.Latency.Milliseconds(),
+	})
+}
+
+// run calls the checker if the cached result has expired and returns the resulting dependency report
+func (c *aggregatedCheck) run(ctx context.Context, cacheTTL time.Duration, clk clock) DependencyReport {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.lastRun.IsZero() || clk.since(c.lastRun) >= cacheTTL {
+		c.latency = c.call(ctx, clk)
+		c.lastRun = clk.now()
+	}
+
+	return DependencyReport{
+		Name:        c.name,
+		Status:      c.state.Status(),
+		StatusCode:  c.state.StatusCode(),
+		Message:     c.state.Message(),
+		Latency:     c.latency,
+		LastChecked: c.state.LastChecked(),
+		LastSuccess: c.state.LastSuccess(),
+		LastFailure: c.state.LastFailure(),
+	}
+}
+
+// call runs the checker against a temporary state, so that a checker that does not honour
+// the context deadline cannot update the check state after it has timed out.
+// The checker runs on a context that keeps the values of the provided context, but is only cancelled by the check timeout.
+func (c *aggregatedCheck) call(ctx context.Context, clk clock) time.Duration {
+	ctx = context.WithoutCancel(ctx)
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	state := health.NewCheckState(c.name)
+	done := make(chan error, 1)
+
+	start := clk.now()
+	go func() {
+		done <- c.checker(ctx, state)
+	}()
+
+	select {
+	case err := <-done:
+		latency := clk.since(start)
+		switch {
+		case state.LastChecked() != nil:
+			c.update(ctx, state.Status(), state.Message(), state.StatusCode())
+		case err != nil:
+			c.update(ctx, health.StatusCritical, err.Error(), 0)
+		default:
+			c.update(ctx, health.StatusCritical, c.name+" check did not report a status", 0)
+		}
+		return latency
+	case <-ctx.Done():
+		c.update(ctx, health.StatusCritical, fmt.Sprintf("%s check did not complete: %s", c.name, ctx.Err()), 0)
+		return clk.since(start)
+	}
+}
+
+func (c *aggregatedCheck) update(ctx context.Context, status, message string, statusCode int) {
+	if err := c.state.Update(status, message, statusCode); err != nil {
+		log.Error(ctx, "failed to update aggregated check state", err, log.Data{"check": c.name})
+	}
+}
@@ -0,0 +1,283 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func newMockChecker(status string, calls *int32) health.Checker {
+	return newSlowMockChecker(status, nil, 0, calls)
+}
+
+// newSlowMockChecker returns a mock checker that advances the provided clock by latency before reporting the provided status
+func newSlowMockChecker(status string, clock *fakeClock, latency time.Duration, calls *int32) health.Checker {
+	return func(ctx context.Context, state *health.CheckState) error {
+		atomic.AddInt32(calls, 1)
+		if clock != nil {
+			clock.Advance(latency)
+		}
+		return state.Update(status, state.Name()+StatusMessage[status], 200)
+	}
+}
+
+func TestAggregator_Check(t *testing.T) {
+	Convey("Given an aggregator with several healthy checks that take 50ms", t, func() {
+		var callsA, callsB int32
+		clock := newFakeClock()
+		aggregator := NewAggregator(time.Second, time.Minute)
+		aggregator.clock = clock.Now
+		So(aggregator.AddCheck("dataset-api", newSlowMockChecker(health.StatusOK, clock, 50*time.Millisecond, &callsA)), ShouldBeNil)
+		So(aggregator.AddCheck("zebedee", newSlowMockChecker(health.StatusOK, clock, 50*time.Millisecond, &callsB)), ShouldBeNil)
+
+		Convey("When the checks are run", func() {
+			report := aggregator.Check(ctx)
+
+			Convey("Then the report contains each dependency in the order they were added", func() {
+				So(report.Status, ShouldEqual, health.StatusOK)
+				So(report.Checks, ShouldHaveLength, 2)
+				So(report.Checks[0].Name, ShouldEqual, "dataset-api")
+				So(report.Checks[0].Status, ShouldEqual, health.StatusOK)
+				So(report.Checks[0].Message, ShouldEqual, "dataset-api"+StatusMessage[health.StatusOK])
+				So(report.Checks[0].Latency, ShouldBeGreaterThanOrEqualTo, 50*time.Millisecond)
+				So(report.Checks[0].LastSuccess, ShouldNotBeNil)
+				So(report.Checks[1].Name, ShouldEqual, "zebedee")
+			})
+
+			Convey("And when the checks are run again within the cache TTL, the cached results are used", func() {
+				clock.Advance(time.Minute - time.Second)
+				aggregator.Check(ctx)
+				So(atomic.LoadInt32(&callsA), ShouldEqual, 1)
+				So(atomic.LoadInt32(&callsB), ShouldEqual, 1)
+			})
+
+			Convey("And when the checks are run again once the cache TTL has expired, the checkers are called again", func() {
+				clock.Advance(time.Minute)
+				aggregator.Check(ctx)
+				So(atomic.LoadInt32(&callsA), ShouldEqual, 2)
+				So(atomic.LoadInt32(&callsB), ShouldEqual, 2)
+			})
+		})
+
+		Convey("When a check with the same name is added", func() {
+			err := aggregator.AddCheck("zebedee", newMockChecker(health.StatusOK, &callsB))
+
+			Convey("Then an error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given an aggregator with checks that can only complete once all of them have started", t, func() {
+		started := &sync.WaitGroup{}
+		started.Add(2)
+		checker := func(ctx context.Context, state *health.CheckState) error {
+			started.Done()
+			started.Wait()
+			return state.Update(health.StatusOK, state.Name()+StatusMessage[health.StatusOK], 200)
+		}
+		aggregator := NewAggregator(5*time.Second, time.Minute)
+		So(aggregator.AddCheck("dataset-api", checker), ShouldBeNil)
+		So(aggregator.AddCheck("zebedee", checker), ShouldBeNil)
+
+		Convey("When the checks are run", func() {
+			report := aggregator.Check(ctx)
+
+			Convey("Then the checks are run concurrently, so none of them times out", func() {
+				So(report.Status, ShouldEqual, health.StatusOK)
+				So(report.Checks[0].Status, ShouldEqual, health.StatusOK)
+				So(report.Checks[1].Status, ShouldEqual, health.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given an aggregator with a zero cache TTL", t, func() {
+		var calls int32
+		aggregator := NewAggregator(time.Second, 0)
+		So(aggregator.AddCheck("dataset-api", newMockChecker(health.StatusOK, &calls)), ShouldBeNil)
+
+		Convey("When the checks are run twice", func() {
+			aggregator.Check(ctx)
+			aggregator.Check(ctx)
+
+			Convey("Then the checker is called every time", func() {
+				So(atomic.LoadInt32(&calls), ShouldEqual, 2)
+			})
+		})
+	})
+
+	Convey("Given an aggregator with a degraded and a slow check", t, func() {
+		var callsA, callsB int32
+		aggregator := NewAggregator(time.Second, time.Minute)
+		So(aggregator.AddCheck("dataset-api", newMockChecker(health.StatusWarning, &callsA)), ShouldBeNil)
+		release := make(chan struct{})
+		Reset(func() { close(release) })
+		So(aggregator.AddCheckWithTimeout("cantabular", func(ctx context.Context, state *health.CheckState) error {
+			atomic.AddInt32(&callsB, 1)
+			<-release // does not complete until the test has finished
+			return state.Update(health.StatusOK, "cantabular"+StatusMessage[health.StatusOK], 200)
+		}, 20*time.Millisecond), ShouldBeNil)
+
+		Convey("When the checks are run", func() {
+			report := aggregator.Check(ctx)
+
+			Convey("Then the slow check is reported as critical after its timeout", func() {
+				So(report.Checks[1].Status, ShouldEqual, health.StatusCritical)
+				So(report.Checks[1].Message, ShouldEqual, "cantabular check did not complete: context deadline exceeded")
+				So(report.Checks[1].LastSuccess, ShouldBeNil)
+			})
+
+			Convey("Then the overall status is the worst of all the checks", func() {
+				So(report.Checks[0].Status, ShouldEqual, health.StatusWarning)
+				So(report.Status, ShouldEqual, health.StatusCritical)
+			})
+		})
+	})
+
+	Convey("Given an aggregator with a check that only completes once the context of the caller is cancelled", t, func() {
+		var calls int32
+		callerCtx, cancel := context.WithCancel(ctx)
+		aggregator := NewAggregator(time.Second, time.Minute)
+		So(aggregator.AddCheck("cantabular", func(ctx context.Context, state *health.CheckState) error {
+			atomic.AddInt32(&calls, 1)
+			cancel()
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			return state.Update(health.StatusOK, "cantabular"+StatusMessage[health.StatusOK], 200)
+		}), ShouldBeNil)
+
+		Convey("When the checks are run with the context of the caller", func() {
+			report := aggregator.Check(callerCtx)
+
+			Convey("Then the check is not cancelled along with the caller context, and its successful result is reported", func() {
+				So(callerCtx.Err(), ShouldEqual, context.Canceled)
+				So(report.Status, ShouldEqual, health.StatusOK)
+				So(aggregator.LastReport(), ShouldEqual, report)
+			})
+
+			Convey("And when the checks are run again, the cached successful result is used", func() {
+				report := aggregator.Check(ctx)
+				So(atomic.LoadInt32(&calls), ShouldEqual, 1)
+				So(report.Status, ShouldEqual, health.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given an aggregator with a check that fails without updating its state", t, func() {
+		aggregator := NewAggregator(time.Second, time.Minute)
+		So(aggregator.AddCheck("files-api", func(ctx context.Context, state *health.CheckState) error {
+			return errors.New("unexpected error")
+		}), ShouldBeNil)
+
+		Convey("When the checks are run", func() {
+			report := aggregator.Check(ctx)
+
+			Convey("Then the check is reported as critical with the returned error", func() {
+				So(report.Status, ShouldEqual, health.StatusCritical)
+				So(report.Checks[0].Message, ShouldEqual, "unexpected error")
+			})
+		})
+	})
+}
+
+func TestAggregator_ServeHTTP(t *testing.T) {
+	Convey("Given an aggregator with a degraded check", t, func() {
+		var calls int32
+		aggregator := NewAggregator(time.Second, time.Minute)
+		So(aggregator.AddCheck("dataset-api", newMockChecker(health.StatusWarning, &calls)), ShouldBeNil)
+
+		Convey("When the handler is called", func() {
+			req := httptest.NewRequest(http.MethodGet, "/health", nil)
+			rr := httptest.NewRecorder()
+			aggregator.ServeHTTP(rr, req)
+
+			Convey("Then the combined report is returned with a status code matching the overall status", func() {
+				So(rr.Code, ShouldEqual, http.StatusTooManyRequests)
+				So(rr.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+
+				var body map[string]interface{}
+				So(json.Unmarshal(rr.Body.Bytes(), &body), ShouldBeNil)
+				So(body["status"], ShouldEqual, health.StatusWarning)
+
+				checks := body["checks"].([]interface{})
+				So(checks, ShouldHaveLength, 1)
+				check := checks[0].(map[string]interface{})
+				So(check["name"], ShouldEqual, "dataset-api")
+				So(check["status"], ShouldEqual, health.StatusWarning)
+				So(check, ShouldContainKey, "latency_ms")
+				So(check, ShouldContainKey, "last_success")
+			})
+		})
+
+		Convey("When the handler is called for a request that has been cancelled by the client", func() {
+			reqCtx, cancel := context.WithCancel(ctx)
+			cancel()
+			req := httptest.NewRequest(http.MethodGet, "/health", nil).WithContext(reqCtx)
+			aggregator.ServeHTTP(httptest.NewRecorder(), req)
+
+			Convey("Then the checks are run regardless and their results are cached", func() {
+				So(atomic.LoadInt32(&calls), ShouldEqual, 1)
+
+				report := aggregator.Check(ctx)
+				So(atomic.LoadInt32(&calls), ShouldEqual, 1)
+				So(report.Status, ShouldEqual, health.StatusWarning)
+			})
+		})
+	})
+}
+
+func TestAggregator_Start(t *testing.T) {
+	Convey("Given an aggregator that has not run its checks", t, func() {
+		var calls int32
+		aggregator := NewAggregator(time.Second, 0)
+		So(aggregator.AddCheck("dataset-api", newMockChecker(health.StatusOK, &calls)), ShouldBeNil)
+
+		Convey("Then there is no last report", func() {
+			So(aggregator.LastReport(), ShouldBeNil)
+		})
+
+		Convey("When the aggregator is started", func() {
+			startCtx, cancel := context.WithCancel(ctx)
+			aggregator.Start(startCtx, 10*time.Millisecond)
+			defer cancel()
+
+			Convey("Then the checks are run immediately", func() {
+				So(atomic.LoadInt32(&calls), ShouldBeGreaterThanOrEqualTo, 1)
+			})
+
+			Convey("Then the checks are run again after the interval", func() {
+				So(waitFor(func() bool { return atomic.LoadInt32(&calls) >= 2 }, 5*time.Second), ShouldBeTrue)
+			})
+
+			Convey("Then the last report is available", func() {
+				report := aggregator.LastReport()
+				So(report, ShouldNotBeNil)
+				dependency, ok := report.Dependency("dataset-api")
+				So(ok, ShouldBeTrue)
+				So(dependency.Status, ShouldEqual, health.StatusOK)
+			})
+		})
+	})
+}
+
+// waitFor polls the provided condition until it is true, returning false if it is still false after the provided timeout
+func waitFor(condition func() bool, timeout time.Duration) bool {
+	deadline := time.Now().Add(timeout)
+	for !condition() {
+		if time.Now().After(deadline) {
+			return false
+		}
+		time.Sleep(time.Millisecond)
+	}
+	return true
+}
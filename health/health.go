@@ -3,9 +3,12 @@ package health
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -26,6 +29,7 @@ type ErrInvalidAppResponse struct {
 	ExpectedCode int
 	ActualCode   int
 	URI          string
+	response     dperrors.ResponseContext
 }
 
 // Client represents an app client
@@ -33,6 +37,13 @@ type Client struct {
 	Client dphttp.Clienter
 	URL    string
 	Name   string
+
+	// LatencyThresholds, if set, degrade the check status when the app responds slowly
+	LatencyThresholds LatencyThresholds
+
+	reportMutex sync.RWMutex
+	lastReport  *Report
+	clock       clock
 }
 
 // NewClient creates a new instance of Client with a given app url
@@ -73,57 +84,129 @@ func (e ErrInvalidAppResponse) Error() string {
 	)
 }
 
-// Checker calls an app health endpoint and returns a check object to the caller
+// Code returns the status code received from the app if an error is returned
+func (e ErrInvalidAppResponse) Code() int {
+	return e.ActualCode
+}
+
+// LogData returns the details of the response received from the app
+func (e ErrInvalidAppResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["expected_code"] = e.ExpectedCode
+	logData["status_code"] = e.ActualCode
+	logData["uri"] = e.URI
+	return logData
+}
+
+// Checker calls an app health endpoint and returns a check object to the caller.
+// If the app responds with a dp-healthcheck JSON body, its status and nested checks
+// are taken into account, and the parsed report is made available through LastReport.
+// If LatencyThresholds are configured, a slow response degrades the check status and
+// the measured latency is recorded in the check message.
 func (c *Client) Checker(ctx context.Context, state *health.CheckState) error {
 	service := c.Name
 	logData := log.Data{
 		"service": service,
 	}
 
-	code, err := c.get(ctx, "/health")
+	start := c.clock.now()
+	code, body, err := c.get(ctx, "/health")
 	// Apps may still have /healthcheck endpoint
 	// instead of a /health one
 	if code == http.StatusNotFound || code == http.StatusUnauthorized {
-		code, err = c.get(ctx, "/healthcheck")
+		start = c.clock.now()
+		code, body, err = c.get(ctx, "/healthcheck")
 	}
+	latency := c.clock.since(start)
 	if err != nil {
 		log.Error(ctx, "failed to request service health", err, logData)
 	}
 
+	report := parseReport(body)
+	c.setLastReport(report)
+
+	var status string
 	switch code {
 	case 0: // When there is a problem with the client return error in message
 		return state.Update(health.StatusCritical, err.Error(), 0)
 	case 200:
-		message := generateMessage(service, health.StatusOK)
-		return state.Update(health.StatusOK, message, code)
+		status = health.StatusOK
 	case 429:
-		message := generateMessage(service, health.StatusWarning)
-		return state.Update(health.StatusWarning, message, code)
+		status = health.StatusWarning
 	default:
-		message := generateMessage(service, health.StatusCritical)
-		return state.Update(health.StatusCritical, message, code)
+		status = health.StatusCritical
 	}
+
+	var details []string
+	if report != nil {
+		status = worstStatus(status, report.Status)
+		if failing := failingChecksMessage(report); failing != "" {
+			details = append(details, failing)
+		}
+	}
+	if c.LatencyThresholds.Enabled() {
+		status = worstStatus(status, c.LatencyThresholds.Status(latency))
+		details = append(details, c.LatencyThresholds.Message(latency))
+	}
+
+	message := generateMessage(service, status)
+	for _, detail := range details {
+		message += " - " + detail
+	}
+
+	return state.Update(status, message, code)
 }
 
-func (c *Client) get(ctx context.Context, path string) (int, error) {
+// LastReport returns the health report parsed from the most recent Checker call,
+// or nil if the app did not respond with a valid dp-healthcheck JSON body
+func (c *Client) LastReport() *Report {
+	c.reportMutex.RLock()
+	defer c.reportMutex.RUnlock()
+
+	return c.lastReport
+}
+
+func (c *Client) setLastReport(report *Report) {
+	c.reportMutex.Lock()
+	defer c.reportMutex.Unlock()
+
+	c.lastReport = report
+}
+
+func (c *Client) get(ctx context.Context, path string) (int, []byte, error) {
 	clientlog.Do(ctx, "retrieving service health", c.Name, c.URL)
 
 	req, err := http.NewRequest("GET", c.URL+path, nil)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 
 	resp, err := c.Client.Do(ctx, req)
 	if err != nil {
-		return 0, err
+		return 0, nil, err
 	}
 	defer closeResponseBody(ctx, resp)
 
-	if resp.StatusCode < 200 || (resp.StatusCode > 399 && resp.StatusCode != 429) {
-		return resp.StatusCode, ErrInvalidAppResponse{http.StatusOK, resp.StatusCode, req.URL.Path}
+	invalid := resp.StatusCode < 200 || (resp.StatusCode > 399 && resp.StatusCode != 429)
+
+	var response dperrors.ResponseContext
+	if invalid {
+		response = dperrors.NewResponseContext(resp)
+	}
+
+	var body []byte
+	if resp.Body != nil {
+		body, err = io.ReadAll(resp.Body)
+		if err != nil {
+			log.Error(ctx, "failed to read service health response body", err, log.Data{"service": c.Name})
+		}
+	}
+
+	if invalid {
+		return resp.StatusCode, body, ErrInvalidAppResponse{http.StatusOK, resp.StatusCode, req.URL.Path, response}
 	}
 
-	return resp.StatusCode, nil
+	return resp.StatusCode, body, nil
 }
 
 // closeResponseBody closes the response body and logs an error if unsuccessful
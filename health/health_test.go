@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -21,6 +23,28 @@ const apiName = "api"
 
 var ctx = context.Background()
 
+// fakeClock is a clock that only advances when requested, so that latency can be measured deterministically
+type fakeClock struct {
+	mutex sync.Mutex
+	t     time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{t: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.t = c.t.Add(d)
+}
+
 func getMockAPI(expectRequest http.Request, mockedHTTPResponse MockedHTTPResponse) *Client {
 	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != expectRequest.Method {
@@ -175,3 +199,212 @@ func TestClient_GetOutput(t *testing.T) {
 		})
 	})
 }
+
+func TestClient_Get(t *testing.T) {
+
+	Convey("When health endpoint returns status Critical", t, func() {
+		mockedAPI := getMockAPI(
+			http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: 500, Body: "{\"status\": \"CRITICAL\"}"},
+		)
+
+		code, body, err := mockedAPI.get(ctx, "/health")
+
+		Convey("Then the body is returned along with the expected error, which has the response details in its log data", func() {
+			So(code, ShouldEqual, 500)
+			So(string(body), ShouldEqual, "{\"status\": \"CRITICAL\"}\n")
+			So(err, ShouldHaveSameTypeAs, ErrInvalidAppResponse{})
+			So(err.(ErrInvalidAppResponse).Code(), ShouldEqual, 500)
+
+			logData := dperrors.LogData(err)
+			So(logData["method"], ShouldEqual, http.MethodGet)
+			So(logData["url"], ShouldEqual, mockedAPI.URL+"/health")
+			So(logData["response_body"], ShouldEqual, "{\"status\": \"CRITICAL\"}")
+		})
+	})
+}
+
+func TestClient_CheckerReport(t *testing.T) {
+	initialTime := time.Now().UTC()
+
+	Convey("Given a downstream service that reports a WARNING status with a 200 status code", t, func() {
+		mockedAPI := getMockAPI(
+			http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: 200, Body: `{"status": "WARNING", "checks": [
+				{"name": "mongodb", "status": "CRITICAL", "message": "mongodb is unavailable"},
+				{"name": "kafka", "status": "OK", "message": "kafka is ok"}
+			]}`},
+		)
+
+		check := CreateCheckState(apiName)
+
+		Convey("When the checker is called", func() {
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state reports a warning, naming the failing nested checks", func() {
+				So(check.StatusCode(), ShouldEqual, 200)
+				So(check.Status(), ShouldEqual, health.StatusWarning)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusWarning]+" - failing checks: mongodb (CRITICAL)")
+				So(*check.LastFailure(), ShouldHappenAfter, initialTime)
+				So(check.LastSuccess(), ShouldBeNil)
+			})
+
+			Convey("And the parsed report is exposed to the caller", func() {
+				report := mockedAPI.LastReport()
+				So(report, ShouldNotBeNil)
+				So(report.Status, ShouldEqual, health.StatusWarning)
+				So(report.Checks, ShouldHaveLength, 2)
+				So(report.FailingChecks(), ShouldHaveLength, 1)
+				So(report.FailingChecks()[0].Name(), ShouldEqual, "mongodb")
+				So(report.FailingChecks()[0].Message(), ShouldEqual, "mongodb is unavailable")
+			})
+		})
+	})
+
+	Convey("Given a downstream service that reports a CRITICAL status with a 500 status code", t, func() {
+		mockedAPI := getMockAPI(
+			http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: 500, Body: `{"status": "CRITICAL", "checks": [
+				{"name": "mongodb", "status": "CRITICAL"},
+				{"name": "zebedee", "status": "WARNING"}
+			]}`},
+		)
+
+		check := CreateCheckState(apiName)
+
+		Convey("When the checker is called", func() {
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state is critical and all failing nested checks are named", func() {
+				So(check.StatusCode(), ShouldEqual, 500)
+				So(check.Status(), ShouldEqual, health.StatusCritical)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusCritical]+" - failing checks: mongodb (CRITICAL), zebedee (WARNING)")
+			})
+		})
+	})
+
+	Convey("Given a downstream service that does not respond with a dp-healthcheck body", t, func() {
+		mockedAPI := getMockAPI(
+			http.Request{Method: "GET"},
+			MockedHTTPResponse{StatusCode: 200, Body: "not json"},
+		)
+
+		check := CreateCheckState(apiName)
+
+		Convey("When the checker is called", func() {
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state is derived from the status code only", func() {
+				So(check.Status(), ShouldEqual, health.StatusOK)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusOK])
+			})
+
+			Convey("And no report is exposed", func() {
+				So(mockedAPI.LastReport(), ShouldBeNil)
+			})
+		})
+	})
+}
+
+func TestClient_CheckerLatency(t *testing.T) {
+	Convey("Given a downstream service that responds with status OK after 50ms", t, func() {
+		clock := newFakeClock()
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clock.Advance(50 * time.Millisecond)
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, `{"status": "OK"}`)
+		}))
+		defer ts.Close()
+
+		mockedAPI := NewClient(apiName, ts.URL)
+		mockedAPI.clock = clock.Now
+		check := CreateCheckState(apiName)
+
+		Convey("When no latency thresholds are configured", func() {
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state is OK and the message is unchanged", func() {
+				So(check.Status(), ShouldEqual, health.StatusOK)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusOK])
+			})
+		})
+
+		Convey("When the latency is below the configured thresholds", func() {
+			mockedAPI.LatencyThresholds = LatencyThresholds{Warning: time.Second, Critical: 2 * time.Second}
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state is OK and the latency is recorded in the message", func() {
+				So(check.Status(), ShouldEqual, health.StatusOK)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusOK]+" - latency: 50ms")
+			})
+		})
+
+		Convey("When the latency exceeds the warning threshold", func() {
+			mockedAPI.LatencyThresholds = LatencyThresholds{Warning: 10 * time.Millisecond, Critical: time.Second}
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state is Warning", func() {
+				So(check.StatusCode(), ShouldEqual, 200)
+				So(check.Status(), ShouldEqual, health.StatusWarning)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusWarning]+" - latency: 50ms exceeds warning threshold of 10ms")
+			})
+		})
+
+		Convey("When the latency exceeds the critical threshold", func() {
+			mockedAPI.LatencyThresholds = LatencyThresholds{Warning: 5 * time.Millisecond, Critical: 10 * time.Millisecond}
+			err := mockedAPI.Checker(ctx, &check)
+			So(err, ShouldBeNil)
+
+			Convey("Then the check state is Critical", func() {
+				So(check.StatusCode(), ShouldEqual, 200)
+				So(check.Status(), ShouldEqual, health.StatusCritical)
+				So(check.Message(), ShouldEqual, apiName+StatusMessage[health.StatusCritical]+" - latency: 50ms exceeds critical threshold of 10ms")
+			})
+		})
+	})
+}
+
+func TestLatencyThresholds(t *testing.T) {
+	Convey("Given latency thresholds with only a critical bound", t, func() {
+		thresholds := LatencyThresholds{Critical: time.Second}
+
+		Convey("Then they are enabled", func() {
+			So(thresholds.Enabled(), ShouldBeTrue)
+		})
+
+		Convey("Then a latency below the critical bound is OK", func() {
+			So(thresholds.Status(900*time.Millisecond), ShouldEqual, health.StatusOK)
+			So(thresholds.Message(900*time.Millisecond), ShouldEqual, "latency: 900ms")
+		})
+
+		Convey("Then a latency above the critical bound is Critical", func() {
+			So(thresholds.Status(1500*time.Millisecond), ShouldEqual, health.StatusCritical)
+			So(thresholds.Message(1500*time.Millisecond), ShouldEqual, "latency: 1.5s exceeds critical threshold of 1s")
+		})
+	})
+
+	Convey("Given latency thresholds and a latency just above the warning bound that rounds down to it", t, func() {
+		thresholds := LatencyThresholds{Warning: 100 * time.Millisecond}
+		latency := 100*time.Millisecond + 200*time.Microsecond
+
+		Convey("Then the message agrees with the status computed for the unrounded latency", func() {
+			So(thresholds.Status(latency), ShouldEqual, health.StatusWarning)
+			So(thresholds.Message(latency), ShouldEqual, "latency: 100ms exceeds warning threshold of 100ms")
+		})
+	})
+
+	Convey("Given zero latency thresholds", t, func() {
+		thresholds := LatencyThresholds{}
+
+		Convey("Then they are disabled and never degrade the status", func() {
+			So(thresholds.Enabled(), ShouldBeFalse)
+			So(thresholds.Status(time.Hour), ShouldEqual, health.StatusOK)
+		})
+	})
+}
@@ -0,0 +1,64 @@
+package health
+
+import (
+	"fmt"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// LatencyThresholds defines the response times above which an otherwise successful
+// health check is reported as degraded (Warning) or unavailable (Critical).
+// A zero value disables the corresponding threshold.
+type LatencyThresholds struct {
+	Warning  time.Duration
+	Critical time.Duration
+}
+
+// Enabled returns true if at least one of the thresholds is set
+func (t LatencyThresholds) Enabled() bool {
+	return t.Warning > 0 || t.Critical > 0
+}
+
+// Status returns the check status corresponding to the provided latency
+func (t LatencyThresholds) Status(latency time.Duration) string {
+	switch {
+	case t.Critical > 0 && latency > t.Critical:
+		return health.StatusCritical
+	case t.Warning > 0 && latency > t.Warning:
+		return health.StatusWarning
+	default:
+		return health.StatusOK
+	}
+}
+
+// Message returns a description of the provided latency, including the threshold it exceeds, if any.
+// The threshold is determined by Status for the provided latency, which is only rounded for display.
+func (t LatencyThresholds) Message(latency time.Duration) string {
+	rounded := latency.Round(time.Millisecond)
+
+	switch t.Status(latency) {
+	case health.StatusCritical:
+		return fmt.Sprintf("latency: %s exceeds critical threshold of %s", rounded, t.Critical)
+	case health.StatusWarning:
+		return fmt.Sprintf("latency: %s exceeds warning threshold of %s", rounded, t.Warning)
+	default:
+		return fmt.Sprintf("latency: %s", rounded)
+	}
+}
+
+// clock returns the current time, and is used to measure latency.
+// A nil clock uses time.Now; tests provide a fake clock so that latency can be measured deterministically.
+type clock func() time.Time
+
+func (c clock) now() time.Time {
+	if c == nil {
+		return time.Now()
+	}
+	return c()
+}
+
+// since returns the time elapsed since t, according to the clock
+func (c clock) since(t time.Time) time.Duration {
+	return c.now().Sub(t)
+}
@@ -0,0 +1,88 @@
+package health
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
+)
+
+// Report represents the health report returned by a downstream dp service
+// that uses dp-healthcheck to serve its /health endpoint
+type Report struct {
+	Status    string               `json:"status"`
+	Version   health.VersionInfo   `json:"version"`
+	Uptime    time.Duration        `json:"uptime"`
+	StartTime time.Time            `json:"start_time"`
+	Checks    []*health.CheckState `json:"checks"`
+}
+
+// FailingChecks returns the nested checks of the report that are not in an OK state
+func (r *Report) FailingChecks() []*health.CheckState {
+	if r == nil {
+		return nil
+	}
+
+	var failing []*health.CheckState
+	for _, check := range r.Checks {
+		if check != nil && check.Status() != health.StatusOK {
+			failing = append(failing, check)
+		}
+	}
+	return failing
+}
+
+// parseReport unmarshals a downstream health response body into a Report.
+// A nil Report is returned if the body is not a valid dp-healthcheck response.
+func parseReport(b []byte) *Report {
+	if len(b) == 0 {
+		return nil
+	}
+
+	var report Report
+	if err := json.Unmarshal(b, &report); err != nil {
+		return nil
+	}
+
+	if !isValidStatus(report.Status) {
+		return nil
+	}
+
+	return &report
+}
+
+// failingChecksMessage returns a description of the failing nested checks in
+// the provided report, or an empty string if there are none
+func failingChecksMessage(report *Report) string {
+	failing := report.FailingChecks()
+	if len(failing) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(failing))
+	for _, check := range failing {
+		names = append(names, check.Name()+" ("+check.Status()+")")
+	}
+	return "failing checks: " + strings.Join(names, ", ")
+}
+
+func isValidStatus(status string) bool {
+	switch status {
+	case health.StatusOK, health.StatusWarning, health.StatusCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// worstStatus returns the most severe of the two provided statuses
+func worstStatus(a, b string) string {
+	if a == health.StatusCritical || b == health.StatusCritical {
+		return health.StatusCritical
+	}
+	if a == health.StatusWarning || b == health.StatusWarning {
+		return health.StatusWarning
+	}
+	return health.StatusOK
+}
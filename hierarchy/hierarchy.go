@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -21,6 +22,7 @@ type ErrInvalidHierarchyAPIResponse struct {
 	expectedCode int
 	actualCode   int
 	uri          string
+	response     dperrors.ResponseContext
 }
 
 // NewErrInvalidHierarchyAPIResponse construct a new ErrInvalidHierarchyAPIResponse from the values provided.
@@ -32,6 +34,16 @@ func NewErrInvalidHierarchyAPIResponse(expectedCode, actualCode int, uri string)
 	}
 }
 
+// newErrInvalidHierarchyAPIResponse creates a new ErrInvalidHierarchyAPIResponse from the unexpected response
+func newErrInvalidHierarchyAPIResponse(resp *http.Response, expectedCode int, uri string) error {
+	return &ErrInvalidHierarchyAPIResponse{
+		expectedCode: expectedCode,
+		actualCode:   resp.StatusCode,
+		uri:          uri,
+		response:     dperrors.NewResponseContext(resp),
+	}
+}
+
 // Error should be called by the user to print out the stringified version of the error
 func (e ErrInvalidHierarchyAPIResponse) Error() string {
 	return fmt.Sprintf("invalid response from hierarchy api - should be: %d, got: %d, path: %s",
@@ -46,6 +58,15 @@ func (e ErrInvalidHierarchyAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from hierarchy api
+func (e ErrInvalidHierarchyAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["expected_code"] = e.expectedCode
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 var _ error = ErrInvalidHierarchyAPIResponse{}
 
 // Client is a hierarchy api client which can be used to make requests to the server
@@ -74,8 +95,11 @@ func New(hierarchyAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -127,7 +151,7 @@ func (c *Client) getHierarchy(ctx context.Context, path string) (Model, error) {
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return m, NewErrInvalidHierarchyAPIResponse(http.StatusOK, resp.StatusCode, path)
+		return m, newErrInvalidHierarchyAPIResponse(resp, http.StatusOK, path)
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
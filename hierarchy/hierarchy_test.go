@@ -9,9 +9,11 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
+	"github.com/ONSdigital/log.go/v2/log"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -43,6 +45,14 @@ func TestErrInvalidHierarchyAPIResponse(t *testing.T) {
 			So(err.Error(), ShouldResemble, "invalid response from hierarchy api - should be: 200, got: 400, path: /hierarchies/foo/bar")
 		})
 
+		Convey("Then LogData() returns the expected codes and path", func() {
+			So(dperrors.LogData(err), ShouldResemble, log.Data{
+				"expected_code": http.StatusOK,
+				"status_code":   http.StatusBadRequest,
+				"uri":           "/hierarchies/foo/bar",
+			})
+		})
+
 		Convey("Then Code() returns the actual http stauts code", func() {
 			So(err.(*ErrInvalidHierarchyAPIResponse).Code(), ShouldEqual, http.StatusBadRequest)
 		})
@@ -213,6 +223,8 @@ func TestClient_GetRoot(t *testing.T) {
 		ts, mockedAPI := getMockHierarchyAPI(http.Request{Method: http.MethodGet}, MockedHTTPResponse{StatusCode: http.StatusBadRequest, Body: ""})
 		_, err := mockedAPI.GetRoot(ctx, instanceID, name)
 		expectedErr := NewErrInvalidHierarchyAPIResponse(http.StatusOK, http.StatusBadRequest, "/hierarchies/foo/bar")
+		So(err, ShouldHaveSameTypeAs, expectedErr)
+		err.(*ErrInvalidHierarchyAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, expectedErr)
 		ts.Close()
 	})
@@ -222,6 +234,15 @@ func TestClient_GetRoot(t *testing.T) {
 		mockedAPI.hcCli.Client.SetMaxRetries(2)
 		_, err := mockedAPI.GetRoot(ctx, instanceID, name)
 		expectedErr := NewErrInvalidHierarchyAPIResponse(http.StatusOK, http.StatusInternalServerError, "/hierarchies/foo/bar")
+		So(err, ShouldHaveSameTypeAs, expectedErr)
+		logData := dperrors.LogData(err)
+		So(logData["method"], ShouldEqual, http.MethodGet)
+		So(logData["url"], ShouldEqual, ts.URL+"/hierarchies/foo/bar")
+		So(logData["request_id"], ShouldNotBeEmpty)
+		So(logData["response_body"], ShouldEqual, "qux")
+		So(logData["response_headers"], ShouldResemble, map[string]string{"Content-Type": "text/plain; charset=utf-8"})
+
+		err.(*ErrInvalidHierarchyAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, expectedErr)
 		ts.Close()
 	})
@@ -252,6 +273,8 @@ func TestClient_GetChild(t *testing.T) {
 		ts, mockedAPI := getMockHierarchyAPI(http.Request{Method: http.MethodGet}, MockedHTTPResponse{StatusCode: http.StatusBadRequest, Body: ""})
 		_, err := mockedAPI.GetChild(ctx, instanceID, name, code)
 		expectedErr := NewErrInvalidHierarchyAPIResponse(http.StatusOK, http.StatusBadRequest, "/hierarchies/foo/bar/baz")
+		So(err, ShouldHaveSameTypeAs, expectedErr)
+		err.(*ErrInvalidHierarchyAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, expectedErr)
 		ts.Close()
 	})
@@ -261,6 +284,8 @@ func TestClient_GetChild(t *testing.T) {
 		mockedAPI.hcCli.Client.SetMaxRetries(2)
 		_, err := mockedAPI.GetChild(ctx, instanceID, name, code)
 		expectedErr := NewErrInvalidHierarchyAPIResponse(http.StatusOK, http.StatusInternalServerError, "/hierarchies/foo/bar/baz")
+		So(err, ShouldHaveSameTypeAs, expectedErr)
+		err.(*ErrInvalidHierarchyAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, expectedErr)
 		ts.Close()
 	})
@@ -289,6 +314,8 @@ func TestClient_GetHierarchy(t *testing.T) {
 		ts, mockedAPI := getMockHierarchyAPI(http.Request{Method: http.MethodGet}, MockedHTTPResponse{StatusCode: http.StatusBadRequest, Body: ""})
 		_, err := mockedAPI.getHierarchy(ctx, path)
 		expectedErr := NewErrInvalidHierarchyAPIResponse(http.StatusOK, http.StatusBadRequest, "/hierarchies/foo/bar")
+		So(err, ShouldHaveSameTypeAs, expectedErr)
+		err.(*ErrInvalidHierarchyAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, expectedErr)
 		ts.Close()
 	})
@@ -298,6 +325,8 @@ func TestClient_GetHierarchy(t *testing.T) {
 		mockedAPI.hcCli.Client.SetMaxRetries(2)
 		_, err := mockedAPI.getHierarchy(ctx, path)
 		expectedErr := NewErrInvalidHierarchyAPIResponse(http.StatusOK, http.StatusInternalServerError, "/hierarchies/foo/bar")
+		So(err, ShouldHaveSameTypeAs, expectedErr)
+		err.(*ErrInvalidHierarchyAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, expectedErr)
 		ts.Close()
 	})
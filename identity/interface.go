@@ -1,9 +1,11 @@
 package identity
 
 //go:generate moq -out mock/token_identity.go -pkg mock . TokenIdentity
+//go:generate moq -out mock/request_checker.go -pkg mock . RequestChecker
 
 import (
 	"context"
+	"net/http"
 
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
 )
@@ -12,3 +14,8 @@ import (
 type TokenIdentity interface {
 	CheckTokenIdentity(ctx context.Context, token string, tokenType TokenType) (*dprequest.IdentityResponse, error)
 }
+
+// RequestChecker is the Client used by the Authenticator middleware to check the identity of callers
+type RequestChecker interface {
+	CheckRequest(req *http.Request, florenceToken, serviceAuthToken string) (context.Context, int, AuthFailure, error)
+}
@@ -0,0 +1,148 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// AuthRule iota enum defines which callers are allowed to access a route
+type AuthRule int
+
+// Possible authentication rules
+const (
+	// AuthRulePublic allows any caller, without checking their identity
+	AuthRulePublic AuthRule = iota
+	// AuthRuleUser only allows callers providing a valid florence (user) token
+	AuthRuleUser
+	// AuthRuleService only allows callers providing a valid service token
+	AuthRuleService
+	// AuthRuleUserOrService allows callers providing either a valid florence token or a valid service token
+	AuthRuleUserOrService
+)
+
+var authRules = []string{"Public", "User", "Service", "UserOrService"}
+
+// Values of the authentication rules
+func (r AuthRule) String() string {
+	if r < 0 || int(r) >= len(authRules) {
+		return fmt.Sprintf("AuthRule(%d)", r)
+	}
+	return authRules[r]
+}
+
+// Error codes returned in the body of rejected requests
+const (
+	ErrCodeUnauthorised        = "Unauthorised"
+	ErrCodeInternalServerError = "InternalServerError"
+)
+
+// Authenticator is a http middleware that checks the identity of callers against the identity API,
+// storing the user and caller identities in the request context for the next handlers
+type Authenticator struct {
+	checker RequestChecker
+}
+
+// NewAuthenticator creates a new Authenticator that checks requests with the provided RequestChecker (e.g. identity.Client)
+func NewAuthenticator(checker RequestChecker) *Authenticator {
+	return &Authenticator{
+		checker: checker,
+	}
+}
+
+// Require creates a middleware that only executes the next handler for callers allowed by the provided rule.
+// Unauthenticated requests are rejected with a JSON error body, in the format understood by errors.FromBody
+func (a *Authenticator) Require(rule AuthRule) func(h http.Handler) http.Handler {
+	return func(nextHandler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if rule == AuthRulePublic {
+				nextHandler.ServeHTTP(w, req)
+				return
+			}
+
+			ctx := req.Context()
+			logData := log.Data{
+				"auth_rule": rule.String(),
+				"path":      req.URL.Path,
+				"method":    req.Method,
+			}
+
+			florenceToken, err := headers.GetUserAuthToken(req)
+			if err != nil && headers.IsNotErrNotFound(err) {
+				log.Error(ctx, "error getting florence token from request", err, logData)
+				writeAuthError(ctx, w, http.StatusInternalServerError, ErrCodeInternalServerError, "failed to read request headers")
+				return
+			}
+
+			serviceAuthToken, err := headers.GetServiceAuthToken(req)
+			if err != nil && headers.IsNotErrNotFound(err) {
+				log.Error(ctx, "error getting service token from request", err, logData)
+				writeAuthError(ctx, w, http.StatusInternalServerError, ErrCodeInternalServerError, "failed to read request headers")
+				return
+			}
+
+			switch rule {
+			case AuthRuleUser:
+				serviceAuthToken = ""
+				if len(florenceToken) == 0 {
+					log.Info(ctx, "rejecting request without a florence token", logData)
+					writeAuthError(ctx, w, http.StatusUnauthorized, ErrCodeUnauthorised, "a user token is required")
+					return
+				}
+			case AuthRuleService:
+				florenceToken = ""
+				if len(serviceAuthToken) == 0 {
+					log.Info(ctx, "rejecting request without a service token", logData)
+					writeAuthError(ctx, w, http.StatusUnauthorized, ErrCodeUnauthorised, "a service token is required")
+					return
+				}
+			}
+
+			authCtx, statusCode, authFailure, err := a.checker.CheckRequest(req, florenceToken, serviceAuthToken)
+			if err != nil {
+				log.Error(ctx, "error checking identity of request", err, logData)
+				writeAuthError(ctx, w, http.StatusInternalServerError, ErrCodeInternalServerError, "failed to check the identity of the caller")
+				return
+			}
+			if authFailure != nil {
+				logData["status_code"] = statusCode
+				if statusCode >= http.StatusInternalServerError {
+					// the identity API failed, so the caller is not known to be unauthorised
+					log.Error(ctx, "identity API failed to check the identity of request", authFailure, logData)
+					writeAuthError(ctx, w, statusCode, ErrCodeInternalServerError, "failed to check the identity of the caller")
+					return
+				}
+				log.Info(ctx, "rejecting request that failed authentication", logData)
+				writeAuthError(ctx, w, http.StatusUnauthorized, ErrCodeUnauthorised, authFailure.Error())
+				return
+			}
+
+			nextHandler.ServeHTTP(w, req.WithContext(authCtx))
+		})
+	}
+}
+
+// writeAuthError writes a JSON error body with the provided status code
+func writeAuthError(ctx context.Context, w http.ResponseWriter, statusCode int, code, description string) {
+	b, err := json.Marshal(dperrors.JsonErrors{
+		Errors: []dperrors.JsonError{
+			{Code: code, Description: description},
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to marshal authentication error", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	if _, err = w.Write(b); err != nil {
+		log.Error(ctx, "failed to write authentication error", err)
+	}
+}
@@ -0,0 +1,199 @@
+package identity_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/identity"
+	"github.com/ONSdigital/dp-api-clients-go/v2/identity/mock"
+	dprequest "github.com/ONSdigital/dp-net/v2/request"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+const (
+	testFlorenceToken = "florence-token"
+	testServiceToken  = "service-token"
+	testUserID        = "fred@ons.gov.uk"
+	testCallerID      = "externalCaller"
+)
+
+func newRequestCheckerMock() *mock.RequestCheckerMock {
+	return &mock.RequestCheckerMock{
+		CheckRequestFunc: func(req *http.Request, florenceToken string, serviceAuthToken string) (context.Context, int, identity.AuthFailure, error) {
+			ctx := context.WithValue(req.Context(), dprequest.UserIdentityKey, testUserID)
+			ctx = context.WithValue(ctx, dprequest.CallerIdentityKey, testCallerID)
+			return ctx, http.StatusOK, nil, nil
+		},
+	}
+}
+
+// nextHandler writes the user and caller identities found in the request context
+var nextHandler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+	user, _ := req.Context().Value(dprequest.UserIdentityKey).(string)
+	caller, _ := req.Context().Value(dprequest.CallerIdentityKey).(string)
+	io.WriteString(w, user+"|"+caller)
+})
+
+func serve(authenticator *identity.Authenticator, rule identity.AuthRule, florenceToken, serviceToken string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/datasets", nil)
+	if florenceToken != "" {
+		req.Header.Set("X-Florence-Token", florenceToken)
+	}
+	if serviceToken != "" {
+		req.Header.Set("Authorization", "Bearer "+serviceToken)
+	}
+
+	rr := httptest.NewRecorder()
+	authenticator.Require(rule)(nextHandler).ServeHTTP(rr, req)
+	return rr
+}
+
+func shouldBeAuthError(rr *httptest.ResponseRecorder, statusCode int, code string) {
+	So(rr.Code, ShouldEqual, statusCode)
+	So(rr.Header().Get("Content-Type"), ShouldEqual, "application/json; charset=utf-8")
+	err := dperrors.FromBody(strings.NewReader(rr.Body.String()))
+	So(err.Error(), ShouldStartWith, code+": ")
+}
+
+func TestAuthenticator_Require(t *testing.T) {
+
+	Convey("Given an Authenticator with a successful request checker", t, func() {
+		checker := newRequestCheckerMock()
+		authenticator := identity.NewAuthenticator(checker)
+
+		Convey("When a public route is requested without tokens", func() {
+			rr := serve(authenticator, identity.AuthRulePublic, "", "")
+
+			Convey("Then the next handler is executed without checking the identity of the caller", func() {
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(rr.Body.String(), ShouldEqual, "|")
+				So(checker.CheckRequestCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a user route is requested with a florence token and a service token", func() {
+			rr := serve(authenticator, identity.AuthRuleUser, testFlorenceToken, testServiceToken)
+
+			Convey("Then only the florence token is checked", func() {
+				So(checker.CheckRequestCalls(), ShouldHaveLength, 1)
+				So(checker.CheckRequestCalls()[0].FlorenceToken, ShouldEqual, testFlorenceToken)
+				So(checker.CheckRequestCalls()[0].ServiceAuthToken, ShouldEqual, "")
+			})
+
+			Convey("Then the next handler is executed with the identities in the context", func() {
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(rr.Body.String(), ShouldEqual, testUserID+"|"+testCallerID)
+			})
+		})
+
+		Convey("When a user route is requested with only a service token", func() {
+			rr := serve(authenticator, identity.AuthRuleUser, "", testServiceToken)
+
+			Convey("Then the request is rejected without checking the identity of the caller", func() {
+				shouldBeAuthError(rr, http.StatusUnauthorized, identity.ErrCodeUnauthorised)
+				So(checker.CheckRequestCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a service route is requested with a florence token and a service token", func() {
+			rr := serve(authenticator, identity.AuthRuleService, testFlorenceToken, testServiceToken)
+
+			Convey("Then only the service token is checked", func() {
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(checker.CheckRequestCalls(), ShouldHaveLength, 1)
+				So(checker.CheckRequestCalls()[0].FlorenceToken, ShouldEqual, "")
+				So(checker.CheckRequestCalls()[0].ServiceAuthToken, ShouldEqual, testServiceToken)
+			})
+		})
+
+		Convey("When a service route is requested with only a florence token", func() {
+			rr := serve(authenticator, identity.AuthRuleService, testFlorenceToken, "")
+
+			Convey("Then the request is rejected without checking the identity of the caller", func() {
+				shouldBeAuthError(rr, http.StatusUnauthorized, identity.ErrCodeUnauthorised)
+				So(checker.CheckRequestCalls(), ShouldHaveLength, 0)
+			})
+		})
+
+		Convey("When a user or service route is requested with both tokens", func() {
+			rr := serve(authenticator, identity.AuthRuleUserOrService, testFlorenceToken, testServiceToken)
+
+			Convey("Then both tokens are provided to the request checker", func() {
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(checker.CheckRequestCalls(), ShouldHaveLength, 1)
+				So(checker.CheckRequestCalls()[0].FlorenceToken, ShouldEqual, testFlorenceToken)
+				So(checker.CheckRequestCalls()[0].ServiceAuthToken, ShouldEqual, testServiceToken)
+			})
+		})
+	})
+
+	Convey("Given an Authenticator with a request checker that fails authentication", t, func() {
+		checker := &mock.RequestCheckerMock{
+			CheckRequestFunc: func(req *http.Request, florenceToken string, serviceAuthToken string) (context.Context, int, identity.AuthFailure, error) {
+				return req.Context(), http.StatusNotFound, errors.New("unable to determine the user or service making the request"), nil
+			},
+		}
+		authenticator := identity.NewAuthenticator(checker)
+
+		Convey("When a user or service route is requested", func() {
+			rr := serve(authenticator, identity.AuthRuleUserOrService, testFlorenceToken, "")
+
+			Convey("Then the request is rejected with an unauthorised JSON error", func() {
+				shouldBeAuthError(rr, http.StatusUnauthorized, identity.ErrCodeUnauthorised)
+				So(rr.Body.String(), ShouldContainSubstring, "unable to determine the user or service making the request")
+			})
+		})
+	})
+
+	Convey("Given an Authenticator with a request checker that fails authentication because the identity API is unavailable", t, func() {
+		checker := &mock.RequestCheckerMock{
+			CheckRequestFunc: func(req *http.Request, florenceToken string, serviceAuthToken string) (context.Context, int, identity.AuthFailure, error) {
+				return req.Context(), http.StatusBadGateway, errors.New("unexpected status code returned from AuthAPI"), nil
+			},
+		}
+		authenticator := identity.NewAuthenticator(checker)
+
+		Convey("When a user or service route is requested", func() {
+			rr := serve(authenticator, identity.AuthRuleUserOrService, testFlorenceToken, "")
+
+			Convey("Then the request is rejected with the status returned by the checker and an internal server error JSON error", func() {
+				shouldBeAuthError(rr, http.StatusBadGateway, identity.ErrCodeInternalServerError)
+			})
+		})
+	})
+
+	Convey("Given an Authenticator with a request checker that returns an error", t, func() {
+		checker := &mock.RequestCheckerMock{
+			CheckRequestFunc: func(req *http.Request, florenceToken string, serviceAuthToken string) (context.Context, int, identity.AuthFailure, error) {
+				return req.Context(), http.StatusInternalServerError, nil, errors.New("identity API unavailable")
+			},
+		}
+		authenticator := identity.NewAuthenticator(checker)
+
+		Convey("When a user or service route is requested", func() {
+			rr := serve(authenticator, identity.AuthRuleUserOrService, "", testServiceToken)
+
+			Convey("Then the request is rejected with an internal server error JSON error", func() {
+				shouldBeAuthError(rr, http.StatusInternalServerError, identity.ErrCodeInternalServerError)
+			})
+		})
+	})
+}
+
+func TestAuthRuleString(t *testing.T) {
+	Convey("The names of the authentication rules are returned", t, func() {
+		So(identity.AuthRulePublic.String(), ShouldEqual, "Public")
+		So(identity.AuthRuleUserOrService.String(), ShouldEqual, "UserOrService")
+	})
+
+	Convey("Values outside the defined authentication rules are returned without panicking", t, func() {
+		So(identity.AuthRule(4).String(), ShouldEqual, "AuthRule(4)")
+		So(identity.AuthRule(-1).String(), ShouldEqual, "AuthRule(-1)")
+	})
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/ONSdigital/log.go/v2/log"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 )
 
@@ -25,6 +26,7 @@ type ErrInvalidImageAPIResponse struct {
 	actualCode int
 	uri        string
 	body       string
+	response   dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -41,6 +43,14 @@ func (e ErrInvalidImageAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from image api
+func (e ErrInvalidImageAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 // compile time check that ErrInvalidImageAPIResponse satisfies the error interface
 var _ error = ErrInvalidImageAPIResponse{}
 
@@ -69,8 +79,11 @@ func NewAPIClient(imageAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of ImageAPI Client,
 // reusing the URL and Clienter from the provided healthcheck client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -366,6 +379,7 @@ func NewImageAPIResponse(resp *http.Response, uri string) (e *ErrInvalidImageAPI
 	e = &ErrInvalidImageAPIResponse{
 		actualCode: resp.StatusCode,
 		uri:        uri,
+		response:   dperrors.NewResponseContext(resp),
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		b, err := ioutil.ReadAll(resp.Body)
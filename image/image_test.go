@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -65,10 +66,12 @@ func TestClient_New(t *testing.T) {
 
 	Convey("Given an existing healthcheck client", t, func() {
 		hcClient := health.NewClient("generic", testHost)
-		Convey("The creating a new iamge API client providing it, results in a new client with the expected URL and name", func() {
+		hcClient.LatencyThresholds = health.LatencyThresholds{Warning: time.Second, Critical: 5 * time.Second}
+		Convey("The creating a new iamge API client providing it, results in a new client with the expected URL, name and latency thresholds", func() {
 			imageClient := NewWithHealthClient(hcClient)
 			So(imageClient.URL(), ShouldEqual, testHost)
 			So(imageClient.HealthClient().Name, ShouldEqual, "image-api")
+			So(imageClient.HealthClient().LatencyThresholds, ShouldResemble, hcClient.LatencyThresholds)
 		})
 	})
 }
@@ -411,6 +414,7 @@ func TestClient_GetImage(t *testing.T) {
 
 			Convey("then the expected error is returned", func() {
 				So(err.Error(), ShouldResemble, errors.Errorf("invalid response: 404 from image api: http://localhost:8080/images/123, body: resource not found").Error())
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "resource not found")
 			})
 
 			Convey("and dphttpclient.Do is called 1 time", func() {
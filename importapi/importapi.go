@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -68,6 +69,7 @@ type ErrInvalidAPIResponse struct {
 	actualCode int
 	uri        string
 	body       string
+	response   dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -86,6 +88,14 @@ func (e ErrInvalidAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from the api
+func (e ErrInvalidAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 var _ error = ErrInvalidAPIResponse{}
 
 // ImportJob comes from the Import API and links an import job to its (other) instances
@@ -280,6 +290,7 @@ func NewAPIResponse(resp *http.Response, uri string) (e *ErrInvalidAPIResponse)
 	e = &ErrInvalidAPIResponse{
 		actualCode: resp.StatusCode,
 		uri:        uri,
+		response:   dperrors.NewResponseContext(resp),
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		body, err := getBody(resp)
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-mocking/httpmocks"
@@ -254,6 +255,8 @@ func TestGetImportJob(t *testing.T) {
 	Convey("When no import-job is returned", t, func(c C) {
 		mockedAPI := getMockImportAPI(c, http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: 404, Body: ""})
 		job, err := mockedAPI.GetImportJob(ctx, jobID, serviceToken)
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidAPIResponse{})
+		err.(*ErrInvalidAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, &ErrInvalidAPIResponse{
 			actualCode: http.StatusNotFound,
 			uri:        fmt.Sprintf("%s/jobs/jid1", mockedAPI.url),
@@ -273,6 +276,8 @@ func TestGetImportJob(t *testing.T) {
 		mockedAPI := getMockImportAPI(c, http.Request{Method: "GET"}, MockedHTTPResponse{StatusCode: 500, Body: "[]"})
 		_, err := mockedAPI.GetImportJob(ctx, jobID, serviceToken)
 		So(err, ShouldNotBeNil)
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidAPIResponse{})
+		err.(*ErrInvalidAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, &ErrInvalidAPIResponse{
 			actualCode: http.StatusInternalServerError,
 			uri:        fmt.Sprintf("%s/jobs/jid1", mockedAPI.url),
@@ -310,6 +315,8 @@ func TestUpdateImportJobState(t *testing.T) {
 		mockedAPI := getMockImportAPI(c, http.Request{Method: "PUT"}, MockedHTTPResponse{StatusCode: 400, Body: ""})
 		err := mockedAPI.UpdateImportJobState(ctx, jobID, serviceToken, StateSubmitted)
 		So(err, ShouldNotBeNil)
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidAPIResponse{})
+		err.(*ErrInvalidAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, &ErrInvalidAPIResponse{
 			actualCode: http.StatusBadRequest,
 			uri:        fmt.Sprintf("%s/jobs/jid0", mockedAPI.url),
@@ -321,6 +328,13 @@ func TestUpdateImportJobState(t *testing.T) {
 		mockedAPI := getMockImportAPI(c, http.Request{Method: "PUT"}, MockedHTTPResponse{StatusCode: 500, Body: "dnm"})
 		err := mockedAPI.UpdateImportJobState(ctx, jobID, serviceToken, StateSubmitted)
 		So(err, ShouldNotBeNil)
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidAPIResponse{})
+		logData := dperrors.LogData(err)
+		So(logData["method"], ShouldEqual, http.MethodPut)
+		So(logData["url"], ShouldEqual, fmt.Sprintf("%s/jobs/jid0", mockedAPI.url))
+		So(logData["request_id"], ShouldNotBeEmpty)
+		So(logData["response_body"], ShouldEqual, "dnm")
+		err.(*ErrInvalidAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, &ErrInvalidAPIResponse{
 			actualCode: http.StatusInternalServerError,
 			uri:        fmt.Sprintf("%s/jobs/jid0", mockedAPI.url),
@@ -353,6 +367,8 @@ func TestIncreaseProcessedInstanceCount(t *testing.T) {
 		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodPut}, MockedHTTPResponse{StatusCode: http.StatusBadRequest, Body: ""})
 		procInst, err := mockedAPI.IncreaseProcessedInstanceCount(ctx, jobID, serviceToken, instanceID)
 		So(err, ShouldNotBeNil)
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidAPIResponse{})
+		err.(*ErrInvalidAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, &ErrInvalidAPIResponse{
 			actualCode: http.StatusBadRequest,
 			uri:        fmt.Sprintf("%s/jobs/job0/processed/inst0", mockedAPI.url),
@@ -365,6 +381,8 @@ func TestIncreaseProcessedInstanceCount(t *testing.T) {
 		mockedAPI := getMockImportAPI(c, http.Request{Method: http.MethodPut}, MockedHTTPResponse{StatusCode: http.StatusInternalServerError, Body: ""})
 		procInst, err := mockedAPI.IncreaseProcessedInstanceCount(ctx, jobID, serviceToken, instanceID)
 		So(err, ShouldNotBeNil)
+		So(err, ShouldHaveSameTypeAs, &ErrInvalidAPIResponse{})
+		err.(*ErrInvalidAPIResponse).response = dperrors.ResponseContext{}
 		So(err, ShouldResemble, &ErrInvalidAPIResponse{
 			actualCode: http.StatusInternalServerError,
 			uri:        fmt.Sprintf("%s/jobs/job0/processed/inst0", mockedAPI.url),
@@ -11,6 +11,7 @@ import (
 	"net/url"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -39,8 +40,11 @@ func NewAPIClient(interactivesAPIURL, version string) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client, version string) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client), version,
+		hcClient, version,
 	}
 }
 
@@ -68,6 +72,7 @@ type ErrInvalidInteractivesAPIResponse struct {
 	actualCode int
 	uri        string
 	body       string
+	response   dperrors.ResponseContext
 }
 
 func (e ErrInvalidInteractivesAPIResponse) Error() string {
@@ -78,11 +83,25 @@ func (e ErrInvalidInteractivesAPIResponse) Error() string {
 	)
 }
 
+// Code returns the status code received from interactives api if an error is returned
+func (e ErrInvalidInteractivesAPIResponse) Code() int {
+	return e.actualCode
+}
+
+// LogData returns the details of the response received from interactives api
+func (e ErrInvalidInteractivesAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 // NewInteractivesAPIResponse creates an error response, optionally adding body to e when status is 404
 func NewInteractivesAPIResponse(resp *http.Response, uri string) (e *ErrInvalidInteractivesAPIResponse) {
 	e = &ErrInvalidInteractivesAPIResponse{
 		actualCode: resp.StatusCode,
 		uri:        uri,
+		response:   dperrors.NewResponseContext(resp),
 	}
 	if resp.StatusCode == http.StatusNotFound {
 		b, err := ioutil.ReadAll(resp.Body)
@@ -13,6 +13,7 @@ import (
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -80,10 +81,15 @@ func TestClient_GetInteractives(t *testing.T) {
 					actualCode: 404,
 					uri:        "http://localhost:8080/v1/interactives",
 					body:       "[]",
+					response:   dperrors.ResponseContext{BodySnippet: "[]"},
 				})
 				So(i, ShouldBeNil)
 			})
 
+			Convey("the response body is included in the log data of the error", func() {
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "[]")
+			})
+
 			Convey("and dphttpclient.Do is called 1 time with the expected URI", func() {
 				expectedURI := "/v1/interactives"
 				checkRequestBase(httpClient, http.MethodGet, expectedURI)
@@ -2,6 +2,18 @@ package middleware
 
 import (
 	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// Paths of the operational endpoints commonly exposed by services
+const (
+	HealthPath  = "/health"
+	ReadyPath   = "/ready"
+	MetricsPath = "/metrics"
+	DebugPath   = "/debug/*"
 )
 
 // Allowed provides a list of methods for which the handler should be executed
@@ -23,7 +35,7 @@ func (a *Allowed) isMethodAllowed(method string) bool {
 // HealthcheckFilter creates a map for healthcheck endpoints whitelisting, to be provided to the Whitelist generic function
 func HealthcheckFilter(hcHandler func(w http.ResponseWriter, req *http.Request)) map[string]Allowed {
 	return map[string]Allowed{
-		"/health": {
+		HealthPath: {
 			Methods: []string{http.MethodGet},
 			Handler: hcHandler,
 		},
@@ -31,7 +43,9 @@ func HealthcheckFilter(hcHandler func(w http.ResponseWriter, req *http.Request))
 }
 
 // Whitelist creates a middleware that executes whitelisted endpoints
-// The provided whitelist is keyed by path, and contains the handler to use and the methods for which the whitelist applies
+// The provided whitelist is keyed by path, and contains the handler to use and the methods for which the whitelist applies.
+// Only exact paths are matched, and requests with a method that is not allowed are passed to the next handler.
+// Use WhitelistRoutes to match prefixes or templates.
 func Whitelist(whitelist map[string]Allowed) func(h http.Handler) http.Handler {
 	return func(nextHandler http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
@@ -45,3 +59,154 @@ func Whitelist(whitelist map[string]Allowed) func(h http.Handler) http.Handler {
 		})
 	}
 }
+
+// Route defines a whitelisted path pattern, along with the handler to use and the methods for which the whitelist applies.
+// The path can be:
+//   - an exact path, e.g. "/health"
+//   - a prefix ending in "/*", e.g. "/debug/*", which matches "/debug" and any path under it
+//   - a gorilla/mux template, e.g. "/{version:v[0-9]+}/health", optionally ending in "/*" to match as a prefix
+type Route struct {
+	Path string
+	Allowed
+}
+
+// HealthcheckRoute returns a Route whitelisting GET requests to the /health endpoint
+func HealthcheckRoute(hcHandler func(w http.ResponseWriter, req *http.Request)) Route {
+	return Route{Path: HealthPath, Allowed: Allowed{Methods: []string{http.MethodGet}, Handler: hcHandler}}
+}
+
+// ReadyRoute returns a Route whitelisting GET requests to the /ready endpoint
+func ReadyRoute(readyHandler func(w http.ResponseWriter, req *http.Request)) Route {
+	return Route{Path: ReadyPath, Allowed: Allowed{Methods: []string{http.MethodGet}, Handler: readyHandler}}
+}
+
+// MetricsRoute returns a Route whitelisting GET requests to the /metrics endpoint
+func MetricsRoute(metricsHandler func(w http.ResponseWriter, req *http.Request)) Route {
+	return Route{Path: MetricsPath, Allowed: Allowed{Methods: []string{http.MethodGet}, Handler: metricsHandler}}
+}
+
+// DebugRoute returns a Route whitelisting GET and POST requests to any endpoint under /debug (e.g. pprof)
+func DebugRoute(debugHandler func(w http.ResponseWriter, req *http.Request)) Route {
+	return Route{Path: DebugPath, Allowed: Allowed{Methods: []string{http.MethodGet, http.MethodPost}, Handler: debugHandler}}
+}
+
+// OperationalRoutes returns the Routes whitelisting the /health, /ready, /metrics and /debug/* endpoints.
+// Any nil handler is skipped, so that only the endpoints exposed by a service are whitelisted.
+func OperationalRoutes(hcHandler, readyHandler, metricsHandler, debugHandler func(w http.ResponseWriter, req *http.Request)) []Route {
+	routes := []Route{}
+	if hcHandler != nil {
+		routes = append(routes, HealthcheckRoute(hcHandler))
+	}
+	if readyHandler != nil {
+		routes = append(routes, ReadyRoute(readyHandler))
+	}
+	if metricsHandler != nil {
+		routes = append(routes, MetricsRoute(metricsHandler))
+	}
+	if debugHandler != nil {
+		routes = append(routes, DebugRoute(debugHandler))
+	}
+	return routes
+}
+
+// WhitelistRoutes creates a middleware that executes whitelisted endpoints matching the provided Routes, which are evaluated in order.
+// Unlike Whitelist, a request whose path matches a Route but whose method is not allowed by any matching Route
+// is rejected with a 405 Method Not Allowed status and an Allow header listing the allowed methods.
+// An error is returned if any of the Route paths is not a valid template.
+//
+// This is a separate function rather than an extension of Whitelist because patterns can overlap, so they need to be
+// evaluated in a fixed order that a map cannot provide, because an invalid template has to be reported as an error,
+// and because existing Whitelist callers rely on non-allowed methods reaching the next handler instead of a 405.
+func WhitelistRoutes(routes ...Route) (func(h http.Handler) http.Handler, error) {
+	matchers := make([]pathMatcher, len(routes))
+	for i, route := range routes {
+		m, err := newPathMatcher(route.Path)
+		if err != nil {
+			return nil, err
+		}
+		matchers[i] = m
+	}
+
+	return func(nextHandler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			var allowedMethods []string
+			for i, route := range routes {
+				if !matchers[i](req) {
+					continue
+				}
+				if route.isMethodAllowed(req.Method) {
+					route.Handler(w, req)
+					return
+				}
+				allowedMethods = append(allowedMethods, route.Methods...)
+			}
+
+			if len(allowedMethods) > 0 {
+				w.Header().Set("Allow", strings.Join(uniqueSorted(allowedMethods), ", "))
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+
+			nextHandler.ServeHTTP(w, req)
+		})
+	}, nil
+}
+
+// pathMatcher determines if the path of a request matches a Route
+type pathMatcher func(req *http.Request) bool
+
+// newPathMatcher creates a pathMatcher for the provided exact path, prefix or gorilla/mux template
+func newPathMatcher(path string) (pathMatcher, error) {
+	isPrefix := strings.HasSuffix(path, "/*")
+	prefix := strings.TrimSuffix(path, "*")
+
+	if !strings.Contains(path, "{") {
+		if isPrefix {
+			root := strings.TrimSuffix(prefix, "/")
+			return func(req *http.Request) bool {
+				return req.URL.Path == root || strings.HasPrefix(req.URL.Path, prefix)
+			}, nil
+		}
+		return func(req *http.Request) bool {
+			return req.URL.Path == path
+		}, nil
+	}
+
+	router := mux.NewRouter()
+	var routes []*mux.Route
+	if isPrefix {
+		// the root of a prefix is matched too, e.g. "/{version}/debug/*" matches "/v1/debug"
+		routes = append(routes, router.NewRoute().Path(strings.TrimSuffix(prefix, "/")), router.NewRoute().PathPrefix(prefix))
+	} else {
+		routes = append(routes, router.NewRoute().Path(path))
+	}
+	for _, route := range routes {
+		if err := route.GetError(); err != nil {
+			return nil, err
+		}
+	}
+
+	return func(req *http.Request) bool {
+		for _, route := range routes {
+			if route.Match(req, &mux.RouteMatch{}) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// uniqueSorted returns the sorted distinct values of the provided slice
+func uniqueSorted(values []string) []string {
+	unique := map[string]struct{}{}
+	for _, v := range values {
+		unique[v] = struct{}{}
+	}
+
+	result := make([]string, 0, len(unique))
+	for v := range unique {
+		result = append(result, v)
+	}
+	sort.Strings(result)
+	return result
+}
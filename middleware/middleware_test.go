@@ -58,3 +58,98 @@ func TestWhitelist(t *testing.T) {
 	})
 
 }
+
+func TestWhitelistRoutes(t *testing.T) {
+
+	Convey("Given a set of whitelisted routes with exact, prefix and template paths", t, func() {
+
+		handler := func(body string) func(w http.ResponseWriter, req *http.Request) {
+			return func(w http.ResponseWriter, req *http.Request) {
+				io.WriteString(w, body)
+			}
+		}
+
+		nextHandler := func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, defaultBody)
+		}
+
+		routes := append(
+			OperationalRoutes(handler("health"), nil, handler("metrics"), handler("debug")),
+			Route{Path: "/{version:v[0-9]+}/health", Allowed: Allowed{Methods: []string{http.MethodGet}, Handler: handler("versioned health")}},
+			Route{Path: "/{version:v[0-9]+}/health", Allowed: Allowed{Methods: []string{http.MethodHead}, Handler: handler("versioned health head")}},
+			Route{Path: "/static/{asset}/*", Allowed: Allowed{Methods: []string{http.MethodGet}, Handler: handler("static")}},
+		)
+
+		whitelist, err := WhitelistRoutes(routes...)
+		So(err, ShouldBeNil)
+		whitelistedHandler := whitelist(http.HandlerFunc(nextHandler))
+
+		rr := httptest.NewRecorder()
+
+		serve := func(method, path string) {
+			req, err := http.NewRequest(method, path, nil)
+			So(err, ShouldBeNil)
+			whitelistedHandler.ServeHTTP(rr, req)
+		}
+
+		Convey("A request against an exact whitelisted path results in its handler being executed", func() {
+			serve(http.MethodGet, "/health")
+			So(rr.Body.String(), ShouldEqual, "health")
+		})
+
+		Convey("A request against a path under a whitelisted prefix results in its handler being executed", func() {
+			serve(http.MethodGet, "/debug/pprof/heap")
+			So(rr.Body.String(), ShouldEqual, "debug")
+		})
+
+		Convey("A request against the root of a whitelisted prefix results in its handler being executed", func() {
+			serve(http.MethodPost, "/debug")
+			So(rr.Body.String(), ShouldEqual, "debug")
+		})
+
+		Convey("A request against a path matching a whitelisted template results in its handler being executed", func() {
+			serve(http.MethodGet, "/v1/health")
+			So(rr.Body.String(), ShouldEqual, "versioned health")
+		})
+
+		Convey("A request against a path matching a whitelisted template prefix results in its handler being executed", func() {
+			serve(http.MethodGet, "/static/css/main.css")
+			So(rr.Body.String(), ShouldEqual, "static")
+		})
+
+		Convey("A request against the root of a whitelisted template prefix results in its handler being executed", func() {
+			serve(http.MethodGet, "/static/css")
+			So(rr.Body.String(), ShouldEqual, "static")
+		})
+
+		Convey("A request against a path that does not match the template results in the default handler being executed", func() {
+			serve(http.MethodGet, "/version1/health")
+			So(rr.Body.String(), ShouldEqual, defaultBody)
+		})
+
+		Convey("A request against a route whose handler was not provided results in the default handler being executed", func() {
+			serve(http.MethodGet, "/ready")
+			So(rr.Body.String(), ShouldEqual, defaultBody)
+		})
+
+		Convey("A request against a non-whitelisted path results in the default handler being executed", func() {
+			serve(http.MethodGet, "/my_path")
+			So(rr.Body.String(), ShouldEqual, defaultBody)
+		})
+
+		Convey("A request against a whitelisted path with a non-allowed method results in a 405 with the allowed methods", func() {
+			serve(http.MethodDelete, "/v2/health")
+			So(rr.Code, ShouldEqual, http.StatusMethodNotAllowed)
+			So(rr.Header().Get("Allow"), ShouldEqual, "GET, HEAD")
+			So(rr.Body.String(), ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a route with an invalid template", t, func() {
+		_, err := WhitelistRoutes(Route{Path: "/{version/health"})
+
+		Convey("Then creating the whitelist fails", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
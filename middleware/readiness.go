@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	healthcheck "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ErrCodeServiceUnavailable is the error code returned in the body of requests rejected by the Readiness middleware
+const ErrCodeServiceUnavailable = "ServiceUnavailable"
+
+// DefaultReadinessRetryAfter is the value of the Retry-After header of rejected requests when ReadinessConfig.RetryAfter is not set
+const DefaultReadinessRetryAfter = 5 * time.Second
+
+// OperationalPaths contains the paths of the operational endpoints, which are usually exempt from readiness gating
+var OperationalPaths = []string{HealthPath, ReadyPath, MetricsPath, DebugPath}
+
+// ReadinessConfig defines the behaviour of the Readiness middleware
+type ReadinessConfig struct {
+	// Whitelist contains the paths that are always served, in any of the formats supported by Route
+	Whitelist []string
+	// DegradableDependencies contains the names of the dependencies that are allowed to be unavailable
+	DegradableDependencies []string
+	// RetryAfter is the value of the Retry-After header of rejected requests. DefaultReadinessRetryAfter is used if it is not positive
+	RetryAfter time.Duration
+}
+
+// Readiness creates a middleware that rejects requests with a 503 Service Unavailable status and a Retry-After header
+// while any dependency that is not degradable is critical, according to the latest report returned by lastReport
+// (e.g. health.Aggregator.LastReport). Requests are let through if no report is available yet.
+// The names of the unavailable dependencies are logged, but not returned to the caller.
+// An error is returned if any of the whitelisted paths is not a valid template.
+func Readiness(lastReport func() *health.AggregateReport, cfg ReadinessConfig) (func(h http.Handler) http.Handler, error) {
+	matchers := make([]pathMatcher, len(cfg.Whitelist))
+	for i, path := range cfg.Whitelist {
+		m, err := newPathMatcher(path)
+		if err != nil {
+			return nil, err
+		}
+		matchers[i] = m
+	}
+
+	degradable := make(map[string]struct{}, len(cfg.DegradableDependencies))
+	for _, name := range cfg.DegradableDependencies {
+		degradable[name] = struct{}{}
+	}
+
+	if cfg.RetryAfter <= 0 {
+		cfg.RetryAfter = DefaultReadinessRetryAfter
+	}
+	retryAfter := strconv.Itoa(int(math.Ceil(cfg.RetryAfter.Seconds())))
+
+	return func(nextHandler http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			for _, match := range matchers {
+				if match(req) {
+					nextHandler.ServeHTTP(w, req)
+					return
+				}
+			}
+
+			unavailable := unavailableDependencies(lastReport(), degradable)
+			if len(unavailable) == 0 {
+				nextHandler.ServeHTTP(w, req)
+				return
+			}
+
+			ctx := req.Context()
+			log.Warn(ctx, "rejecting request as critical dependencies are unavailable", log.Data{
+				"path":         req.URL.Path,
+				"method":       req.Method,
+				"dependencies": unavailable,
+			})
+
+			w.Header().Set("Retry-After", retryAfter)
+			writeUnavailable(ctx, w, "service temporarily unavailable")
+		})
+	}, nil
+}
+
+// unavailableDependencies returns the names of the critical dependencies in the report that are not degradable
+func unavailableDependencies(report *health.AggregateReport, degradable map[string]struct{}) []string {
+	if report == nil {
+		return nil
+	}
+
+	var unavailable []string
+	for _, check := range report.Checks {
+		if check.Status != healthcheck.StatusCritical {
+			continue
+		}
+		if _, ok := degradable[check.Name]; ok {
+			continue
+		}
+		unavailable = append(unavailable, check.Name)
+	}
+	return unavailable
+}
+
+// writeUnavailable writes a 503 status with a JSON error body
+func writeUnavailable(ctx context.Context, w http.ResponseWriter, description string) {
+	b, err := json.Marshal(dperrors.JsonErrors{
+		Errors: []dperrors.JsonError{
+			{Code: ErrCodeServiceUnavailable, Description: description},
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to marshal readiness error", err)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	if _, err = w.Write(b); err != nil {
+		log.Error(ctx, "failed to write readiness error", err)
+	}
+}
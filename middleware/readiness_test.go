@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	healthcheck "github.com/ONSdigital/dp-healthcheck/healthcheck"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReadiness(t *testing.T) {
+
+	Convey("Given a Readiness middleware with a whitelist and a degradable dependency", t, func() {
+
+		var report *health.AggregateReport
+		lastReport := func() *health.AggregateReport {
+			return report
+		}
+
+		nextHandler := func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, defaultBody)
+		}
+
+		readiness, err := Readiness(lastReport, ReadinessConfig{
+			Whitelist:              OperationalPaths,
+			DegradableDependencies: []string{"image-api"},
+			RetryAfter:             1500 * time.Millisecond,
+		})
+		So(err, ShouldBeNil)
+		gatedHandler := readiness(http.HandlerFunc(nextHandler))
+
+		rr := httptest.NewRecorder()
+
+		serve := func(path string) {
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			So(err, ShouldBeNil)
+			gatedHandler.ServeHTTP(rr, req)
+		}
+
+		Convey("When no health report is available yet, requests are served", func() {
+			serve("/datasets")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(rr.Body.String(), ShouldEqual, defaultBody)
+		})
+
+		Convey("When only a degradable dependency is critical, requests are served", func() {
+			report = &health.AggregateReport{
+				Status: healthcheck.StatusCritical,
+				Checks: []health.DependencyReport{
+					{Name: "dataset-api", Status: healthcheck.StatusWarning},
+					{Name: "image-api", Status: healthcheck.StatusCritical},
+				},
+			}
+			serve("/datasets")
+			So(rr.Code, ShouldEqual, http.StatusOK)
+			So(rr.Body.String(), ShouldEqual, defaultBody)
+		})
+
+		Convey("When a non-degradable dependency is critical", func() {
+			report = &health.AggregateReport{
+				Status: healthcheck.StatusCritical,
+				Checks: []health.DependencyReport{
+					{Name: "dataset-api", Status: healthcheck.StatusCritical},
+					{Name: "zebedee", Status: healthcheck.StatusCritical},
+					{Name: "image-api", Status: healthcheck.StatusCritical},
+				},
+			}
+
+			Convey("Then a request against a non-whitelisted path is rejected with a 503 and a Retry-After header", func() {
+				serve("/datasets")
+				So(rr.Code, ShouldEqual, http.StatusServiceUnavailable)
+				So(rr.Header().Get("Retry-After"), ShouldEqual, "2")
+				So(rr.Body.String(), ShouldEqual, `{"errors":[{"errorCode":"ServiceUnavailable","description":"service temporarily unavailable"}]}`)
+			})
+
+			Convey("Then a request against a whitelisted path is served", func() {
+				serve("/health")
+				So(rr.Code, ShouldEqual, http.StatusOK)
+				So(rr.Body.String(), ShouldEqual, defaultBody)
+			})
+
+			Convey("Then a request against a path under a whitelisted prefix is served", func() {
+				serve("/debug/pprof")
+				So(rr.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Given a Readiness middleware without a Retry-After value and a critical dependency", t, func() {
+		report := &health.AggregateReport{
+			Status: healthcheck.StatusCritical,
+			Checks: []health.DependencyReport{
+				{Name: "dataset-api", Status: healthcheck.StatusCritical},
+			},
+		}
+		readiness, err := Readiness(func() *health.AggregateReport { return report }, ReadinessConfig{})
+		So(err, ShouldBeNil)
+
+		Convey("Then rejected requests have the default Retry-After header", func() {
+			rr := httptest.NewRecorder()
+			req, err := http.NewRequest(http.MethodGet, "/datasets", nil)
+			So(err, ShouldBeNil)
+			readiness(http.NotFoundHandler()).ServeHTTP(rr, req)
+
+			So(rr.Code, ShouldEqual, http.StatusServiceUnavailable)
+			So(rr.Header().Get("Retry-After"), ShouldEqual, "5")
+		})
+	})
+}
@@ -33,8 +33,11 @@ func New(categoryAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of category API Client,
 // reusing the URL and Clienter from the provided healthcheck client
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		hcCli: healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcCli: hcClient,
 	}
 }
 
@@ -33,8 +33,11 @@ func NewAPIClient(releaseCalendarApiUrl string) *Client {
 // NewWithHealthClient creates a new instance of ReleaseCalendarAPI Client,
 // reusing the URL and Clienter from the provided healthcheck client.
 func NewWithHealthClient(hcCli *health.Client) *Client {
+	hcClient := health.NewClientWithClienter(serviceName, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		health.NewClientWithClienter(serviceName, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
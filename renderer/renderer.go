@@ -8,6 +8,7 @@ import (
 	"net/http"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -19,6 +20,7 @@ const service = "renderer"
 // with a status 200
 type ErrInvalidRendererResponse struct {
 	responseCode int
+	response     dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -31,6 +33,13 @@ func (e ErrInvalidRendererResponse) Code() int {
 	return e.responseCode
 }
 
+// LogData returns the details of the response received from renderer
+func (e ErrInvalidRendererResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["status_code"] = e.responseCode
+	return logData
+}
+
 // Renderer represents a renderer client to interact with the dp-frontend-renderer
 type Renderer struct {
 	HcCli *healthcheck.Client
@@ -46,8 +55,11 @@ func New(url string) *Renderer {
 // NewWithHealthClient creates a new instance of Renderer,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Renderer {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Renderer{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -94,7 +106,7 @@ func (r *Renderer) Do(path string, b []byte) ([]byte, error) {
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, ErrInvalidRendererResponse{resp.StatusCode}
+		return nil, ErrInvalidRendererResponse{resp.StatusCode, dperrors.NewResponseContext(resp)}
 	}
 
 	return ioutil.ReadAll(resp.Body)
@@ -1,14 +1,17 @@
 package renderer
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"testing"
 	"time"
 
 	. "github.com/smartystreets/goconvey/convey"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -178,6 +181,28 @@ func TestClient_HealthChecker(t *testing.T) {
 	})
 }
 
+func TestRenderer_Do(t *testing.T) {
+
+	Convey("given clienter.Do returns a 500 response with a body", t, func() {
+		httpClient := newMockHTTPClient(&http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(bytes.NewReader([]byte("template not found"))),
+		}, nil)
+		renderer := newRendererClient(httpClient)
+
+		Convey("when renderer.Do is called", func() {
+			b, err := renderer.Do("dataset-landing-page", nil)
+
+			Convey("then the expected error is returned, with the response body in its log data", func() {
+				So(b, ShouldBeNil)
+				So(err, ShouldHaveSameTypeAs, ErrInvalidRendererResponse{})
+				So(err.(ErrInvalidRendererResponse).Code(), ShouldEqual, http.StatusInternalServerError)
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "template not found")
+			})
+		})
+	})
+}
+
 func newMockHTTPClient(r *http.Response, err error) *dphttp.ClienterMock {
 	return &dphttp.ClienterMock{
 		SetPathsWithNoRetriesFunc: func(paths []string) {
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -36,6 +37,7 @@ type ErrInvalidDimensionSearchAPIResponse struct {
 	expectedCode int
 	actualCode   int
 	uri          string
+	response     dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -52,6 +54,15 @@ func (e ErrInvalidDimensionSearchAPIResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from dimension-search api
+func (e ErrInvalidDimensionSearchAPIResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["expected_code"] = e.expectedCode
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 var _ error = ErrInvalidDimensionSearchAPIResponse{}
 
 // Client is a search api client that can be used to make requests to the server
@@ -69,8 +80,11 @@ func New(dimensionSearchAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -133,7 +147,7 @@ func (c *Client) Dimension(ctx context.Context, datasetID, edition, version, nam
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, &ErrInvalidDimensionSearchAPIResponse{http.StatusOK, resp.StatusCode, uri}
+		return nil, &ErrInvalidDimensionSearchAPIResponse{http.StatusOK, resp.StatusCode, uri, dperrors.NewResponseContext(resp)}
 	}
 
 	err = json.NewDecoder(resp.Body).Decode(&m)
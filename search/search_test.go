@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -201,7 +202,7 @@ func TestDimensionSearchUnit(t *testing.T) {
 
 		Convey("test Dimension no limit returns error if HTTP Status code is not 200", func() {
 
-			expectedError := &ErrInvalidDimensionSearchAPIResponse{http.StatusOK, http.StatusTeapot, "http://localhost:22000/dimension-search/datasets/12345/editions/time-series/versions/1/dimensions/geography?limit=50&offset=1&q=Newport"}
+			expectedError := &ErrInvalidDimensionSearchAPIResponse{expectedCode: http.StatusOK, actualCode: http.StatusTeapot, uri: "http://localhost:22000/dimension-search/datasets/12345/editions/time-series/versions/1/dimensions/geography?limit=50&offset=1&q=Newport"}
 			mockClient := &dphttp.ClienterMock{
 				GetPathsWithNoRetriesFunc: func() []string { return []string{} },
 				SetPathsWithNoRetriesFunc: func([]string) {},
@@ -233,6 +234,26 @@ func TestDimensionSearchUnit(t *testing.T) {
 			})
 
 		})
+
+		Convey("test Dimension returns an error with the response body in its log data if HTTP Status code is not 200", func() {
+			mockClient := &dphttp.ClienterMock{
+				GetPathsWithNoRetriesFunc: func() []string { return []string{} },
+				SetPathsWithNoRetriesFunc: func([]string) {},
+				DoFunc: func(ctx context.Context, req *http.Request) (*http.Response, error) {
+					return &http.Response{
+						StatusCode: http.StatusInternalServerError,
+						Body:       ioutil.NopCloser(bytes.NewReader([]byte("index not found"))),
+					}, nil
+				},
+			}
+
+			hcCli := health.NewClientWithClienter(service, "http://localhost:22000", mockClient)
+			searchCli := NewWithHealthClient(hcCli)
+
+			_, err := searchCli.Dimension(ctx, "12345", "time-series", "1", "geography", "Newport")
+			So(err, ShouldHaveSameTypeAs, &ErrInvalidDimensionSearchAPIResponse{})
+			So(dperrors.LogData(err)["response_body"], ShouldEqual, "index not found")
+		})
 	})
 }
 
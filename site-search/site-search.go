@@ -9,6 +9,7 @@ import (
 	"net/url"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -23,6 +24,7 @@ type ErrInvalidSearchResponse struct {
 	expectedCode int
 	actualCode   int
 	uri          string
+	response     dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -39,6 +41,15 @@ func (e ErrInvalidSearchResponse) Code() int {
 	return e.actualCode
 }
 
+// LogData returns the details of the response received from dp-search-api
+func (e ErrInvalidSearchResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["expected_code"] = e.expectedCode
+	logData["status_code"] = e.actualCode
+	logData["uri"] = e.uri
+	return logData
+}
+
 // compile time check that ErrInvalidSearchResponse satisfies the error interface
 var _ error = ErrInvalidSearchResponse{}
 
@@ -57,8 +68,11 @@ func NewClient(searchAPIURL string) *Client {
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -103,6 +117,7 @@ func NewSearchErrorResponse(resp *http.Response, uri string) (e *ErrInvalidSearc
 		expectedCode: http.StatusOK,
 		actualCode:   resp.StatusCode,
 		uri:          uri,
+		response:     dperrors.NewResponseContext(resp),
 	}
 }
 
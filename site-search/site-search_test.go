@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/headers"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
@@ -220,6 +221,20 @@ func TestClient_GetSearch(t *testing.T) {
 			})
 		})
 	})
+
+	Convey("given a 500 status is returned with a body", t, func() {
+		httpClient := createHTTPClientMock(http.StatusInternalServerError, []byte("elasticsearch unavailable"))
+		searchClient := newSearchClient(httpClient)
+
+		Convey("when GetSearch is called", func() {
+			_, err := searchClient.GetSearch(ctx, userAuthToken, serviceAuthToken, collectionID, url.Values{})
+
+			Convey("then the expected error is returned, with the response body in its log data", func() {
+				So(err, ShouldHaveSameTypeAs, &ErrInvalidSearchResponse{})
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "elasticsearch unavailable")
+			})
+		})
+	})
 }
 
 func TestClient_GetDepartments(t *testing.T) {
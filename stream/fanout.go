@@ -0,0 +1,211 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// ErrNoConsumers is returned when there are no consumers left to write the transformed stream to
+var ErrNoConsumers = errors.New("no consumers left to stream to")
+
+// FanOutPolicy defines how StreamFanOut handles consumer errors
+type FanOutPolicy int
+
+// Possible fan out policies
+const (
+	// FailFast aborts the transformer and all the other consumers as soon as any consumer fails
+	FailFast FanOutPolicy = iota
+	// Isolate detaches any failing consumer, so that the transformer and the other consumers carry on
+	Isolate
+)
+
+// FanOutConfig defines the behaviour of StreamFanOut
+type FanOutConfig struct {
+	// Policy defines how consumer errors are handled
+	Policy FanOutPolicy
+	// BufferSize is the number of writes of the transformer that can be buffered for each consumer.
+	// Once the buffer of any consumer is full, the transformer is blocked until that consumer catches up.
+	// If zero, the transformer is blocked on every write until all the consumers have received it.
+	BufferSize int
+}
+
+// ConsumerError is the error returned by a consumer of StreamFanOut, along with its index in the provided consumers
+type ConsumerError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the message of the consumer error
+func (e *ConsumerError) Error() string {
+	return fmt.Sprintf("consumer %d error: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the error returned by the consumer
+func (e *ConsumerError) Unwrap() error {
+	return e.Err
+}
+
+// fanOutConsumer holds the channel that feeds a consumer and the result of its execution
+type fanOutConsumer struct {
+	index    int
+	chunks   chan []byte
+	done     chan struct{}
+	err      error
+	detached bool
+}
+
+// fanOutWriter is the writer provided to the transformer, which writes a copy of every write to each consumer
+type fanOutWriter struct {
+	ctx       context.Context
+	policy    FanOutPolicy
+	consumers []*fanOutConsumer
+}
+
+// StreamFanOut is a variant of Stream that feeds the transformed stream to multiple consumers concurrently,
+// e.g. to upload a CSV file while its checksum is calculated, without running the transformer more than once.
+// All consumers receive the same data, and the transformer is blocked by any consumer that cannot keep up.
+// Consumer errors are handled according to the policy in the provided config:
+//   - FailFast: the context provided to the transformer and the consumers is cancelled and the error is returned
+//   - Isolate: the failing consumer stops receiving data, and its error is returned once all the other consumers complete
+//
+// This method blocks until all work is complete. Consumer errors are returned as *ConsumerError, joined with any transform error.
+func StreamFanOut(ctx context.Context, body io.ReadCloser, transform Transformer, cfg FanOutConfig, consumers ...Consumer) error {
+	if len(consumers) == 0 {
+		closeResponseBody(ctx, body)
+		return ErrNoConsumers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	w := &fanOutWriter{
+		ctx:       ctx,
+		policy:    cfg.Policy,
+		consumers: make([]*fanOutConsumer, len(consumers)),
+	}
+
+	var errTransform error
+	wg := &sync.WaitGroup{}
+
+	for i, consume := range consumers {
+		c := &fanOutConsumer{
+			index:  i,
+			chunks: make(chan []byte, cfg.BufferSize),
+			done:   make(chan struct{}),
+		}
+		w.consumers[i] = c
+		pipeReader, pipeWriter := io.Pipe()
+
+		// Start go-routine to write the chunks received by this consumer to its pipe.
+		// Once all chunks are written, the pipe writer is closed (with error if 'transform' func returned an error).
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range c.chunks {
+				if _, err := pipeWriter.Write(chunk); err != nil {
+					continue // the consumer has finished, keep draining its chunks so the transformer is not blocked
+				}
+			}
+			if err := pipeWriter.CloseWithError(errTransform); err != nil {
+				log.Error(ctx, "stream error: error closing pipe writer of fan out consumer", err, log.Data{"consumer": c.index})
+			}
+		}()
+
+		// Start go-routine to call the consumer func with the pipe reader
+		wg.Add(1)
+		go func(consume Consumer) {
+			defer wg.Done()
+			c.err = consume(ctx, pipeReader)
+			if c.err != nil && cfg.Policy == FailFast {
+				cancel()
+			}
+			if err := pipeReader.CloseWithError(c.err); err != nil {
+				log.Error(ctx, "stream error: error closing pipe reader of fan out consumer", err, log.Data{"consumer": c.index})
+			}
+			close(c.done)
+		}(consume)
+	}
+
+	errTransform = transform(ctx, body, w)
+	closeResponseBody(ctx, body)
+	if errTransform != nil {
+		cancel()
+	}
+	for _, c := range w.consumers {
+		close(c.chunks)
+	}
+
+	wg.Wait()
+
+	return fanOutError(errTransform, w.consumers, cfg.Policy)
+}
+
+// Write sends a copy of p to every consumer that is still attached,
+// blocking until all of them have received it or have finished
+func (w *fanOutWriter) Write(p []byte) (int, error) {
+	chunk := make([]byte, len(p))
+	copy(chunk, p)
+
+	attached := 0
+	for _, c := range w.consumers {
+		if c.detached {
+			continue
+		}
+
+		select {
+		case c.chunks <- chunk:
+			attached++
+		case <-c.done:
+			c.detached = true
+			if c.err != nil && w.policy == FailFast {
+				return 0, &ConsumerError{Index: c.index, Err: c.err}
+			}
+		case <-w.ctx.Done():
+			return 0, w.ctx.Err()
+		}
+	}
+
+	if attached == 0 {
+		return 0, ErrNoConsumers
+	}
+	return len(p), nil
+}
+
+// fanOutError returns the transform error, unless it was caused by the consumers, joined with the consumer errors.
+// Consumers that were cancelled because another consumer failed with the FailFast policy are not reported.
+func fanOutError(errTransform error, consumers []*fanOutConsumer, policy FanOutPolicy) error {
+	var errs []error
+	var consumerFailed bool
+	for _, c := range consumers {
+		if c.err != nil && !isAbortError(c.err) {
+			consumerFailed = true
+		}
+	}
+
+	causedByConsumers := consumerFailed && (errors.Is(errTransform, ErrNoConsumers) || isAbortError(errTransform))
+	if errTransform != nil && !causedByConsumers {
+		errs = append(errs, fmt.Errorf("transform error: %w", errTransform))
+	}
+
+	for _, c := range consumers {
+		if c.err == nil {
+			continue
+		}
+		if policy == FailFast && consumerFailed && isAbortError(c.err) {
+			continue
+		}
+		errs = append(errs, &ConsumerError{Index: c.index, Err: c.err})
+	}
+
+	return errors.Join(errs...)
+}
+
+// isAbortError returns true if the error was caused by the cancellation of the stream after another consumer failed
+func isAbortError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.As(err, new(*ConsumerError))
+}
@@ -0,0 +1,143 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// chunkedTransformer writes the input in chunks of the provided size, returning any write error
+func chunkedTransformer(chunkSize int) Transformer {
+	return func(ctx context.Context, r io.Reader, w io.Writer) error {
+		buf := make([]byte, chunkSize)
+		_, err := io.CopyBuffer(struct{ io.Writer }{w}, struct{ io.Reader }{r}, buf)
+		return err
+	}
+}
+
+// collectingConsumer returns a consumer that copies everything it reads into the provided buffer
+func collectingConsumer(out *bytes.Buffer) Consumer {
+	return func(ctx context.Context, r io.Reader) error {
+		_, err := io.Copy(out, r)
+		return err
+	}
+}
+
+func TestStreamFanOut(t *testing.T) {
+	input := bytes.Repeat([]byte("cantabular,csv,row\n"), 1000)
+
+	Convey("Given a transformer and several consumers", t, func() {
+		var upload, rows bytes.Buffer
+		var checksum []byte
+
+		consumers := []Consumer{
+			collectingConsumer(&upload),
+			func(ctx context.Context, r io.Reader) error {
+				h := sha256.New()
+				if _, err := io.Copy(h, r); err != nil {
+					return err
+				}
+				checksum = h.Sum(nil)
+				return nil
+			},
+			collectingConsumer(&rows),
+		}
+
+		for _, bufferSize := range []int{0, 4} {
+			Convey(fmt.Sprintf("When the transformed stream is fanned out with a buffer size of %d", bufferSize), func() {
+				err := StreamFanOut(ctx, ioutil.NopCloser(bytes.NewReader(input)), chunkedTransformer(100), FanOutConfig{BufferSize: bufferSize}, consumers...)
+
+				Convey("Then all the consumers receive the whole stream", func() {
+					So(err, ShouldBeNil)
+					So(upload.Bytes(), ShouldResemble, input)
+					So(rows.Bytes(), ShouldResemble, input)
+					expectedChecksum := sha256.Sum256(input)
+					So(checksum, ShouldResemble, expectedChecksum[:])
+				})
+			})
+		}
+	})
+
+	Convey("Given a consumer that fails and another one that consumes everything", t, func() {
+		var out bytes.Buffer
+		failing := func(ctx context.Context, r io.Reader) error {
+			if _, err := r.Read(make([]byte, 10)); err != nil {
+				return err
+			}
+			return errTestConsumer
+		}
+
+		Convey("When the stream is fanned out with the FailFast policy", func() {
+			err := StreamFanOut(ctx, ioutil.NopCloser(bytes.NewReader(input)), chunkedTransformer(100), FanOutConfig{Policy: FailFast}, collectingConsumer(&out), failing)
+
+			Convey("Then only the error of the failing consumer is returned and the stream is aborted", func() {
+				So(err, ShouldNotBeNil)
+				So(errors.Is(err, errTestConsumer), ShouldBeTrue)
+
+				var consumerErr *ConsumerError
+				So(errors.As(err, &consumerErr), ShouldBeTrue)
+				So(consumerErr.Index, ShouldEqual, 1)
+				So(err.Error(), ShouldEqual, "consumer 1 error: consumer error")
+				So(out.Len(), ShouldBeLessThan, len(input))
+			})
+		})
+
+		Convey("When the stream is fanned out with the Isolate policy", func() {
+			err := StreamFanOut(ctx, ioutil.NopCloser(bytes.NewReader(input)), chunkedTransformer(100), FanOutConfig{Policy: Isolate}, collectingConsumer(&out), failing)
+
+			Convey("Then the other consumer receives the whole stream and the error of the failing consumer is returned", func() {
+				So(out.Bytes(), ShouldResemble, input)
+				So(err, ShouldResemble, errors.Join(&ConsumerError{Index: 1, Err: errTestConsumer}))
+			})
+		})
+	})
+
+	Convey("Given a consumer that returns successfully without reading the whole stream", t, func() {
+		var out bytes.Buffer
+		header := func(ctx context.Context, r io.Reader) error {
+			_, err := r.Read(make([]byte, 10))
+			return err
+		}
+
+		Convey("When the stream is fanned out with the FailFast policy", func() {
+			err := StreamFanOut(ctx, ioutil.NopCloser(bytes.NewReader(input)), chunkedTransformer(100), FanOutConfig{Policy: FailFast}, header, collectingConsumer(&out))
+
+			Convey("Then the stream carries on for the other consumer", func() {
+				So(err, ShouldBeNil)
+				So(out.Bytes(), ShouldResemble, input)
+			})
+		})
+	})
+
+	Convey("Given an erroring transformer", t, func() {
+		var errReceived error
+		consume := func(ctx context.Context, r io.Reader) error {
+			_, errReceived = io.Copy(io.Discard, r)
+			return errReceived
+		}
+
+		Convey("When the stream is fanned out", func() {
+			err := StreamFanOut(ctx, ioutil.NopCloser(bytes.NewReader(input)), func(ctx context.Context, r io.Reader, w io.Writer) error {
+				return errTestTransformer
+			}, FanOutConfig{Policy: Isolate}, consume)
+
+			Convey("Then the consumers receive the transform error and both errors are returned", func() {
+				So(errReceived, ShouldEqual, errTestTransformer)
+				So(errors.Is(err, errTestTransformer), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "transform error: transfomer error\nconsumer 0 error: transfomer error")
+			})
+		})
+	})
+
+	Convey("Given no consumers, then StreamFanOut fails", t, func() {
+		err := StreamFanOut(ctx, ioutil.NopCloser(bytes.NewReader(input)), chunkedTransformer(100), FanOutConfig{})
+		So(err, ShouldEqual, ErrNoConsumers)
+	})
+}
@@ -0,0 +1,215 @@
+package jsonstream
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrPathNotFound is returned when the path of an array is not found in the decoded json
+var ErrPathNotFound = errors.New("json path not found")
+
+// ArrayIterator decodes the elements of a JSON array of type T found at a path of nested object fields, one at a time.
+// Any other fields of the objects along the path are skipped. If any of the objects or the array are null,
+// there are no elements to iterate. Once all elements are decoded, the rest of the enclosing objects is
+// decoded and discarded, so that the decoder is left at the end of the value that contained the path.
+//
+//	it := jsonstream.NewArrayIterator[Value](dec, "data.dataset.table.values")
+//	for it.Next() {
+//		v := it.Value()
+//	}
+//	if err := it.Err(); err != nil {
+//		...
+//	}
+type ArrayIterator[T any] struct {
+	dec                   Decoder
+	path                  []string
+	disallowUnknownFields bool
+
+	started bool
+	done    bool
+	depth   int // number of objects along the path that have been opened and not closed
+	index   int
+	value   T
+	err     error
+}
+
+// NewArrayIterator creates a new ArrayIterator for the array at the provided path, e.g. "data.dataset.table.values".
+// The path is relative to the next value of the decoder. If empty, the next value of the decoder is expected to be the array.
+func NewArrayIterator[T any](dec Decoder, path string) *ArrayIterator[T] {
+	var segments []string
+	if path != "" {
+		segments = strings.Split(path, ".")
+	}
+	return &ArrayIterator[T]{
+		dec:   dec,
+		path:  segments,
+		index: -1,
+	}
+}
+
+// DecodeArrayAt decodes the elements of the JSON array of type T at the provided path, calling fn with each of them.
+// It stops at the first error, either decoding the json or returned by fn.
+func DecodeArrayAt[T any](dec Decoder, path string, fn func(v T) error) error {
+	it := NewArrayIterator[T](dec, path)
+	for it.Next() {
+		if err := fn(it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// DisallowUnknownFields causes an error to be returned when an element of the array is an object that contains
+// keys which do not match any non-ignored, exported fields in T. By default, unknown fields are skipped.
+func (it *ArrayIterator[T]) DisallowUnknownFields() {
+	it.disallowUnknownFields = true
+}
+
+// Next decodes the next element of the array, returning false once there are no more elements or an error is found
+func (it *ArrayIterator[T]) Next() bool {
+	if it.done {
+		return false
+	}
+
+	if !it.started {
+		it.started = true
+		if found, err := it.start(); err != nil || !found {
+			it.finish(err)
+			return false
+		}
+	}
+
+	if !it.dec.More() {
+		if err := it.dec.EndComposite(); err != nil {
+			it.finish(fmt.Errorf("error decoding end of json array at %q: %w", it.location(len(it.path)), err))
+			return false
+		}
+		it.finish(nil)
+		return false
+	}
+
+	it.index++
+	var v T
+	if err := it.decodeValue(&v); err != nil {
+		it.finish(fmt.Errorf("error decoding json at %q: %w", it.location(len(it.path)), err))
+		return false
+	}
+	it.value = v
+	return true
+}
+
+// Value returns the last element decoded by Next
+func (it *ArrayIterator[T]) Value() T {
+	return it.value
+}
+
+// Index returns the index in the array of the last element decoded by Next
+func (it *ArrayIterator[T]) Index() int {
+	return it.index
+}
+
+// Err returns the first error found by the iterator, if any
+func (it *ArrayIterator[T]) Err() error {
+	return it.err
+}
+
+// start navigates the objects along the path and decodes the start of the array,
+// returning false if any of them is null
+func (it *ArrayIterator[T]) start() (bool, error) {
+	for i, name := range it.path {
+		isStartObject, err := it.dec.StartObjectComposite()
+		if err != nil {
+			return false, fmt.Errorf("error decoding start of json object at %q: %w", it.location(i), err)
+		}
+		if !isStartObject {
+			return false, nil
+		}
+		it.depth++
+
+		if err := it.findField(name); err != nil {
+			return false, fmt.Errorf("error finding %q in json object at %q: %w", name, it.location(i), err)
+		}
+	}
+
+	isStartArray, err := it.dec.StartArrayComposite()
+	if err != nil {
+		return false, fmt.Errorf("error decoding start of json array at %q: %w", it.location(len(it.path)), err)
+	}
+	return isStartArray, nil
+}
+
+// findField skips the fields of the current object until the one with the provided name is found
+func (it *ArrayIterator[T]) findField(name string) error {
+	for it.dec.More() {
+		field, err := it.dec.DecodeName()
+		if err != nil {
+			return fmt.Errorf("error decoding field: %w", err)
+		}
+		if field == name {
+			return nil
+		}
+		if err := it.dec.SkipValue(); err != nil {
+			return fmt.Errorf("error skipping field %q: %w", field, err)
+		}
+	}
+	return ErrPathNotFound
+}
+
+// finish marks the iterator as done with the provided error. If there is no error,
+// the rest of the objects along the path are decoded and discarded.
+func (it *ArrayIterator[T]) finish(err error) {
+	it.done = true
+	if err != nil {
+		it.err = err
+		return
+	}
+
+	for ; it.depth > 0; it.depth-- {
+		for it.dec.More() {
+			field, err := it.dec.DecodeName()
+			if err != nil {
+				it.err = fmt.Errorf("error decoding field in json object at %q: %w", it.location(it.depth-1), err)
+				return
+			}
+			if err := it.dec.SkipValue(); err != nil {
+				it.err = fmt.Errorf("error skipping field %q in json object at %q: %w", field, it.location(it.depth-1), err)
+				return
+			}
+		}
+		if err := it.dec.EndComposite(); err != nil {
+			it.err = fmt.Errorf("error decoding end of json object at %q: %w", it.location(it.depth-1), err)
+			return
+		}
+	}
+}
+
+// decodeValue decodes the next element of the array into v
+func (it *ArrayIterator[T]) decodeValue(v *T) error {
+	if !it.disallowUnknownFields {
+		return it.dec.Decode(v)
+	}
+
+	var raw json.RawMessage
+	if err := it.dec.Decode(&raw); err != nil {
+		return err
+	}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	d.DisallowUnknownFields()
+	return d.Decode(v)
+}
+
+// location returns the path of the first n objects, followed by the index of the current element if the whole path is reached
+func (it *ArrayIterator[T]) location(n int) string {
+	loc := strings.Join(it.path[:n], ".")
+	if n == len(it.path) && it.index >= 0 {
+		loc = fmt.Sprintf("%s[%d]", loc, it.index)
+	}
+	if loc == "" {
+		return "."
+	}
+	return loc
+}
@@ -0,0 +1,152 @@
+package jsonstream_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream/jsonstream"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type testValue struct {
+	Code  string      `json:"code"`
+	Count json.Number `json:"count"`
+}
+
+const testResponse = `{
+	"errors": null,
+	"data": {
+		"dataset": {
+			"description": {"text": "skipped", "nested": [1, [2, 3], {"a": null}]},
+			"table": {
+				"dimensions": [{"variable": {"name": "city"}}],
+				"values": [
+					{"code": "0", "count": 1},
+					{"code": "1", "count": 2, "label": "Manchester"},
+					{"code": "2", "count": 3}
+				],
+				"error": null
+			}
+		},
+		"extra": true
+	}
+}
+{"next": "value"}`
+
+func TestDecodeArrayAt(t *testing.T) {
+	Convey("Given a json stream with an array of values nested in objects with other fields", t, func() {
+		dec := jsonstream.New(strings.NewReader(testResponse))
+
+		Convey("When the array is decoded with DecodeArrayAt", func() {
+			var values []testValue
+			err := jsonstream.DecodeArrayAt(dec, "data.dataset.table.values", func(v testValue) error {
+				values = append(values, v)
+				return nil
+			})
+
+			Convey("Then all the values are decoded in order, skipping unknown fields", func() {
+				So(err, ShouldBeNil)
+				So(values, ShouldResemble, []testValue{
+					{Code: "0", Count: "1"},
+					{Code: "1", Count: "2"},
+					{Code: "2", Count: "3"},
+				})
+			})
+
+			Convey("Then the decoder is left at the end of the value that contained the array", func() {
+				var next map[string]string
+				So(dec.Decode(&next), ShouldBeNil)
+				So(next, ShouldResemble, map[string]string{"next": "value"})
+			})
+		})
+
+		Convey("When the callback fails", func() {
+			errCallback := errors.New("callback error")
+			calls := 0
+			err := jsonstream.DecodeArrayAt(dec, "data.dataset.table.values", func(v testValue) error {
+				calls++
+				return errCallback
+			})
+
+			Convey("Then decoding stops and the error is returned", func() {
+				So(err, ShouldEqual, errCallback)
+				So(calls, ShouldEqual, 1)
+			})
+		})
+
+		Convey("When the path does not exist", func() {
+			err := jsonstream.DecodeArrayAt(dec, "data.dataset.rows", func(v testValue) error { return nil })
+
+			Convey("Then a path not found error is returned with the path of the object that was searched", func() {
+				So(errors.Is(err, jsonstream.ErrPathNotFound), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, `error finding "rows" in json object at "data.dataset": json path not found`)
+			})
+		})
+
+		Convey("When the path does not lead to an array", func() {
+			err := jsonstream.DecodeArrayAt(dec, "data.dataset.table.dimensions.variable", func(v testValue) error { return nil })
+
+			Convey("Then an error is returned with the path of the unexpected value", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, `error decoding start of json object at "data.dataset.table.dimensions"`)
+			})
+		})
+	})
+
+	Convey("Given a json stream where an object along the path is null", t, func() {
+		dec := jsonstream.New(strings.NewReader(`{"data": null, "errors": [{"message": "not found"}]}`))
+
+		Convey("Then there are no values to decode and the rest of the stream is skipped", func() {
+			calls := 0
+			err := jsonstream.DecodeArrayAt(dec, "data.dataset.table.values", func(v testValue) error {
+				calls++
+				return nil
+			})
+			So(err, ShouldBeNil)
+			So(calls, ShouldEqual, 0)
+			So(dec.More(), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a json stream that is an array, then its values are decoded with an empty path", t, func() {
+		var codes []string
+		err := jsonstream.DecodeArrayAt(jsonstream.New(strings.NewReader(`["a", "b"]`)), "", func(v string) error {
+			codes = append(codes, v)
+			return nil
+		})
+		So(err, ShouldBeNil)
+		So(codes, ShouldResemble, []string{"a", "b"})
+	})
+}
+
+func TestArrayIterator(t *testing.T) {
+	Convey("Given an iterator over an array with an invalid value", t, func() {
+		dec := jsonstream.New(strings.NewReader(`{"values": [{"code": "0", "count": 1}, {"code": 1, "count": 2}]}`))
+		it := jsonstream.NewArrayIterator[testValue](dec, "values")
+
+		Convey("Then the values are decoded until the invalid one, which fails with its path and index", func() {
+			So(it.Next(), ShouldBeTrue)
+			So(it.Value(), ShouldResemble, testValue{Code: "0", Count: "1"})
+			So(it.Index(), ShouldEqual, 0)
+
+			So(it.Next(), ShouldBeFalse)
+			So(it.Err(), ShouldNotBeNil)
+			So(it.Err().Error(), ShouldStartWith, `error decoding json at "values[1]": json: cannot unmarshal number`)
+			So(it.Next(), ShouldBeFalse)
+		})
+	})
+
+	Convey("Given an iterator that disallows unknown fields", t, func() {
+		dec := jsonstream.New(strings.NewReader(testResponse))
+		it := jsonstream.NewArrayIterator[testValue](dec, "data.dataset.table.values")
+		it.DisallowUnknownFields()
+
+		Convey("Then a value with unknown fields fails with its path and index", func() {
+			So(it.Next(), ShouldBeTrue)
+			So(it.Next(), ShouldBeFalse)
+			So(it.Err().Error(), ShouldEqual, `error decoding json at "data.dataset.table.values[1]": json: unknown field "label"`)
+		})
+	})
+}
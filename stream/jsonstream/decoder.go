@@ -103,3 +103,25 @@ func (dec Decoder) DecodeNumber() (json.Number, error) {
 
 	return n, nil
 }
+
+// SkipValue decodes and discards the next value, including all the nested values of an array or object
+func (dec Decoder) SkipValue() error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return fmt.Errorf("error decoding json token: %w", err)
+		}
+
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+
+		if depth == 0 {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package jsonstream
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Encoder writes JSON objects and arrays incrementally, adding the commas between their members
+// and checking that they are correctly nested, so that large arrays can be written one element at a time.
+// Errors are sticky: once a write fails, all subsequent calls return the same error.
+//
+//	enc := jsonstream.NewEncoder(w)
+//	enc.StartObject()
+//	enc.StartArrayField("observations")
+//	for _, o := range observations {
+//		enc.Encode(o)
+//	}
+//	enc.EndArray()
+//	enc.Field("total_observations", len(observations))
+//	enc.EndObject()
+//	err := enc.Close()
+type Encoder struct {
+	w     io.Writer
+	stack []*composite
+	err   error
+}
+
+// composite holds the state of an object or array that is being written
+type composite struct {
+	isObject bool
+	members  int
+	hasName  bool // true if the name of an object member has been written, but not its value
+}
+
+// NewEncoder creates a new Encoder that writes to w
+func NewEncoder(w io.Writer) *Encoder {
+	return &Encoder{w: w}
+}
+
+// StartObject writes the start of a JSON object, i.e. '{'
+func (enc *Encoder) StartObject() error {
+	return enc.start(true, '{')
+}
+
+// StartArray writes the start of a JSON array, i.e. '['
+func (enc *Encoder) StartArray() error {
+	return enc.start(false, '[')
+}
+
+// EndObject writes the end of the current JSON object, i.e. '}'
+func (enc *Encoder) EndObject() error {
+	return enc.end(true, '}')
+}
+
+// EndArray writes the end of the current JSON array, i.e. ']'
+func (enc *Encoder) EndArray() error {
+	return enc.end(false, ']')
+}
+
+// Name writes the name of the next member of the current object, which must be followed by its value
+func (enc *Encoder) Name(name string) error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	c := enc.current()
+	if c == nil || !c.isObject {
+		return enc.fail(fmt.Errorf("json field name %q written outside of an object", name))
+	}
+	if c.hasName {
+		return enc.fail(fmt.Errorf("json field name %q written without a value for the previous name", name))
+	}
+
+	b, err := json.Marshal(name)
+	if err != nil {
+		return enc.fail(fmt.Errorf("error encoding json field name: %w", err))
+	}
+	if c.members > 0 {
+		b = append([]byte{','}, b...)
+	}
+	if err := enc.write(append(b, ':')); err != nil {
+		return err
+	}
+	c.hasName = true
+	return nil
+}
+
+// Encode writes the JSON encoding of v as the next value, i.e. an element of the current array,
+// the value of the current object member, or the top-level value
+func (enc *Encoder) Encode(v interface{}) error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return enc.fail(fmt.Errorf("error encoding json value: %w", err))
+	}
+	return enc.writeValue(b)
+}
+
+// Field writes a member of the current object with the provided name and the JSON encoding of v as its value
+func (enc *Encoder) Field(name string, v interface{}) error {
+	if err := enc.Name(name); err != nil {
+		return err
+	}
+	return enc.Encode(v)
+}
+
+// StartObjectField writes the name of a member of the current object followed by the start of a JSON object as its value
+func (enc *Encoder) StartObjectField(name string) error {
+	if err := enc.Name(name); err != nil {
+		return err
+	}
+	return enc.StartObject()
+}
+
+// StartArrayField writes the name of a member of the current object followed by the start of a JSON array as its value
+func (enc *Encoder) StartArrayField(name string) error {
+	if err := enc.Name(name); err != nil {
+		return err
+	}
+	return enc.StartArray()
+}
+
+// Close returns an error if any write failed or if any object or array has not been ended.
+// It does not close the underlying writer.
+func (enc *Encoder) Close() error {
+	if enc.err != nil {
+		return enc.err
+	}
+	if len(enc.stack) > 0 {
+		return enc.fail(fmt.Errorf("%d json object(s) or array(s) have not been ended", len(enc.stack)))
+	}
+	return nil
+}
+
+// start writes the start delimiter of an object or array as the next value and pushes it to the stack
+func (enc *Encoder) start(isObject bool, delim byte) error {
+	if enc.err != nil {
+		return enc.err
+	}
+	if err := enc.writeValue([]byte{delim}); err != nil {
+		return err
+	}
+	enc.stack = append(enc.stack, &composite{isObject: isObject})
+	return nil
+}
+
+// end writes the end delimiter of the current object or array and pops it from the stack
+func (enc *Encoder) end(isObject bool, delim byte) error {
+	if enc.err != nil {
+		return enc.err
+	}
+
+	c := enc.current()
+	if c == nil || c.isObject != isObject {
+		return enc.fail(fmt.Errorf("unexpected %q: no matching start of json composite", delim))
+	}
+	if c.hasName {
+		return enc.fail(fmt.Errorf("unexpected %q: json field name written without a value", delim))
+	}
+	if err := enc.write([]byte{delim}); err != nil {
+		return err
+	}
+	enc.stack = enc.stack[:len(enc.stack)-1]
+	return nil
+}
+
+// writeValue writes b as the next value, preceded by a comma if it is not the first element of the current array
+func (enc *Encoder) writeValue(b []byte) error {
+	c := enc.current()
+	switch {
+	case c == nil:
+	case c.isObject && !c.hasName:
+		return enc.fail(errors.New("json value written in an object without a field name"))
+	case c.isObject:
+		c.hasName = false
+	case c.members > 0:
+		b = append([]byte{','}, b...)
+	}
+
+	if err := enc.write(b); err != nil {
+		return err
+	}
+	if c != nil {
+		c.members++
+	}
+	return nil
+}
+
+func (enc *Encoder) write(b []byte) error {
+	if _, err := enc.w.Write(b); err != nil {
+		return enc.fail(fmt.Errorf("error writing json: %w", err))
+	}
+	return nil
+}
+
+func (enc *Encoder) fail(err error) error {
+	enc.err = err
+	return err
+}
+
+func (enc *Encoder) current() *composite {
+	if len(enc.stack) == 0 {
+		return nil
+	}
+	return enc.stack[len(enc.stack)-1]
+}
@@ -0,0 +1,99 @@
+package jsonstream_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream/jsonstream"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type errWriter struct{}
+
+func (w errWriter) Write(p []byte) (int, error) { return 0, errors.New("write error") }
+
+func TestEncoder(t *testing.T) {
+	Convey("Given an encoder", t, func() {
+		out := &bytes.Buffer{}
+		enc := jsonstream.NewEncoder(out)
+
+		Convey("When an object with a streamed array followed by other fields is written", func() {
+			So(enc.StartObject(), ShouldBeNil)
+			So(enc.StartArrayField("observations"), ShouldBeNil)
+			for i := 0; i < 3; i++ {
+				So(enc.Encode(testValue{Code: "0", Count: "1"}), ShouldBeNil)
+			}
+			So(enc.EndArray(), ShouldBeNil)
+			So(enc.StartObjectField("links"), ShouldBeNil)
+			So(enc.Field("self", map[string]string{"href": "http://localhost"}), ShouldBeNil)
+			So(enc.EndObject(), ShouldBeNil)
+			So(enc.Field("total_observations", 3), ShouldBeNil)
+			So(enc.EndObject(), ShouldBeNil)
+
+			Convey("Then the expected json is written with commas between the members", func() {
+				So(enc.Close(), ShouldBeNil)
+				So(out.String(), ShouldEqual, `{"observations":[`+
+					`{"code":"0","count":1},{"code":"0","count":1},{"code":"0","count":1}],`+
+					`"links":{"self":{"href":"http://localhost"}},"total_observations":3}`)
+			})
+		})
+
+		Convey("When nested empty arrays and objects are written", func() {
+			enc.StartArray()
+			enc.StartArray()
+			enc.EndArray()
+			enc.StartObject()
+			enc.EndObject()
+			enc.Encode(nil)
+			enc.EndArray()
+
+			Convey("Then the expected json is written", func() {
+				So(enc.Close(), ShouldBeNil)
+				So(out.String(), ShouldEqual, `[[],{},null]`)
+			})
+		})
+
+		Convey("When a value is written in an object without a name", func() {
+			enc.StartObject()
+			err := enc.Encode(1)
+
+			Convey("Then the error is returned by all subsequent calls", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldEqual, "json value written in an object without a field name")
+				So(enc.EndObject(), ShouldEqual, err)
+				So(enc.Close(), ShouldEqual, err)
+			})
+		})
+
+		Convey("Then a name written outside of an object fails", func() {
+			enc.StartArray()
+			So(enc.Name("code"), ShouldNotBeNil)
+		})
+
+		Convey("Then a name written without a value for the previous name fails", func() {
+			enc.StartObject()
+			enc.Name("code")
+			So(enc.Name("label"), ShouldNotBeNil)
+		})
+
+		Convey("Then ending a composite that does not match the started one fails", func() {
+			enc.StartObject()
+			So(enc.EndArray(), ShouldNotBeNil)
+		})
+
+		Convey("Then closing an encoder with composites that have not been ended fails", func() {
+			enc.StartObject()
+			enc.StartArrayField("values")
+			So(enc.Close().Error(), ShouldEqual, "2 json object(s) or array(s) have not been ended")
+		})
+	})
+
+	Convey("Given an encoder with a failing writer, then the write error is returned", t, func() {
+		enc := jsonstream.NewEncoder(errWriter{})
+		err := enc.StartObject()
+		So(err, ShouldNotBeNil)
+		So(err.Error(), ShouldEqual, "error writing json: write error")
+		So(enc.Close(), ShouldEqual, err)
+	})
+}
@@ -0,0 +1,237 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sync"
+	"time"
+)
+
+// Progress is a snapshot of the data that has gone through a Meter
+type Progress struct {
+	Bytes   int64
+	Rows    int64
+	Elapsed time.Duration
+}
+
+// ProgressFunc is called by a Meter to report its progress
+type ProgressFunc = func(p Progress)
+
+// MaxSizeError is the error returned by a Meter when more than its maximum size in bytes goes through it
+type MaxSizeError struct {
+	MaxSize int64
+}
+
+// Error returns the message of the max size error
+func (e *MaxSizeError) Error() string {
+	return fmt.Sprintf("stream exceeded maximum size of %d bytes", e.MaxSize)
+}
+
+// MeterConfig defines the behaviour of a Meter
+type MeterConfig struct {
+	// OnProgress is called with the progress of the meter at most once per Interval, and once more when the stream ends
+	OnProgress ProgressFunc
+	// Interval is the minimum time between calls to OnProgress. If zero, OnProgress is called on every read or write.
+	Interval time.Duration
+	// MaxSize is the maximum number of bytes allowed through the meter. If zero, there is no limit.
+	MaxSize int64
+	// MD5 enables the calculation of a running MD5 checksum
+	MD5 bool
+	// SHA256 enables the calculation of a running SHA256 checksum
+	SHA256 bool
+}
+
+// Meter keeps account of the bytes and rows (newline terminated lines) that go through the readers,
+// writers or transformers it wraps, reporting its progress, calculating checksums and enforcing a maximum size.
+// It is safe to read the progress and checksums of a Meter while the stream is in progress.
+type Meter struct {
+	cfg        MeterConfig
+	mutex      sync.Mutex
+	bytes      int64
+	rows       int64
+	md5        hash.Hash
+	sha256     hash.Hash
+	startedAt  time.Time
+	reportedAt time.Time
+}
+
+// NewMeter creates a new Meter with the provided config
+func NewMeter(cfg MeterConfig) *Meter {
+	m := &Meter{
+		cfg:       cfg,
+		startedAt: time.Now(),
+	}
+	if cfg.MD5 {
+		m.md5 = md5.New()
+	}
+	if cfg.SHA256 {
+		m.sha256 = sha256.New()
+	}
+	return m
+}
+
+// Progress returns the current progress of the meter
+func (m *Meter) Progress() Progress {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return m.progress()
+}
+
+// MD5 returns the hex encoded MD5 checksum of the data that has gone through the meter, or an empty string if not enabled
+func (m *Meter) MD5() string {
+	return m.checksum(m.md5)
+}
+
+// SHA256 returns the hex encoded SHA256 checksum of the data that has gone through the meter, or an empty string if not enabled
+func (m *Meter) SHA256() string {
+	return m.checksum(m.sha256)
+}
+
+// Report calls the OnProgress func, if any, with the current progress of the meter
+func (m *Meter) Report() {
+	if m.cfg.OnProgress == nil {
+		return
+	}
+
+	m.mutex.Lock()
+	m.reportedAt = time.Now()
+	p := m.progress()
+	m.mutex.Unlock()
+
+	m.cfg.OnProgress(p)
+}
+
+// Reader wraps the provided reader so that all the data read from it goes through the meter.
+// The final progress is reported once the reader returns io.EOF.
+func (m *Meter) Reader(r io.Reader) io.Reader {
+	return &meteredReader{r: r, m: m}
+}
+
+// ReadCloser wraps the provided ReadCloser so that all the data read from it goes through the meter, e.g. the
+// content of a download.Response. The final progress is reported once the reader returns io.EOF.
+func (m *Meter) ReadCloser(rc io.ReadCloser) io.ReadCloser {
+	return &meteredReadCloser{meteredReader: meteredReader{r: rc, m: m}, c: rc}
+}
+
+// Writer wraps the provided writer so that all the data written to it goes through the meter.
+// Report should be called once all the data is written to report the final progress.
+func (m *Meter) Writer(w io.Writer) io.Writer {
+	return &meteredWriter{w: w, m: m}
+}
+
+// Transformer returns a Transformer that copies its input unchanged through the meter, reporting the final
+// progress once it is done. It can be used as a stage of a Pipeline to meter the output of the previous stage.
+func (m *Meter) Transformer() Transformer {
+	return func(ctx context.Context, r io.Reader, w io.Writer) error {
+		_, err := io.Copy(m.Writer(w), r)
+		if err == nil {
+			m.Report()
+		}
+		return err
+	}
+}
+
+// Consumer wraps the provided Consumer so that all the data it reads goes through the meter
+func (m *Meter) Consumer(consume Consumer) Consumer {
+	return func(ctx context.Context, r io.Reader) error {
+		return consume(ctx, m.Reader(r))
+	}
+}
+
+// add accounts for p, returning the number of bytes of p that are allowed by the max size
+// and a MaxSizeError if not all of them are. OnProgress is called if the interval has elapsed.
+func (m *Meter) add(p []byte) (int, error) {
+	var err error
+
+	m.mutex.Lock()
+	if m.cfg.MaxSize > 0 && m.bytes+int64(len(p)) > m.cfg.MaxSize {
+		p = p[:m.cfg.MaxSize-m.bytes]
+		err = &MaxSizeError{MaxSize: m.cfg.MaxSize}
+	}
+
+	m.bytes += int64(len(p))
+	m.rows += int64(bytes.Count(p, []byte{'\n'}))
+	for _, h := range []hash.Hash{m.md5, m.sha256} {
+		if h != nil {
+			h.Write(p)
+		}
+	}
+	report := m.cfg.OnProgress != nil && time.Since(m.reportedAt) >= m.cfg.Interval
+	m.mutex.Unlock()
+
+	if report {
+		m.Report()
+	}
+	return len(p), err
+}
+
+func (m *Meter) progress() Progress {
+	return Progress{
+		Bytes:   m.bytes,
+		Rows:    m.rows,
+		Elapsed: time.Since(m.startedAt),
+	}
+}
+
+func (m *Meter) checksum(h hash.Hash) string {
+	if h == nil {
+		return ""
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// meteredReader is a reader that accounts for all the data read from the underlying reader in a meter
+type meteredReader struct {
+	r io.Reader
+	m *Meter
+}
+
+func (mr *meteredReader) Read(p []byte) (int, error) {
+	n, err := mr.r.Read(p)
+	if n > 0 {
+		var errMeter error
+		if n, errMeter = mr.m.add(p[:n]); errMeter != nil {
+			return n, errMeter
+		}
+	}
+	if err == io.EOF {
+		mr.m.Report()
+	}
+	return n, err
+}
+
+// meteredReadCloser is a meteredReader that closes the underlying ReadCloser
+type meteredReadCloser struct {
+	meteredReader
+	c io.Closer
+}
+
+func (mrc *meteredReadCloser) Close() error {
+	return mrc.c.Close()
+}
+
+// meteredWriter is a writer that accounts for all the data written to the underlying writer in a meter
+type meteredWriter struct {
+	w io.Writer
+	m *Meter
+}
+
+func (mw *meteredWriter) Write(p []byte) (int, error) {
+	allowed, errMeter := mw.m.add(p)
+	n, err := mw.w.Write(p[:allowed])
+	if err != nil {
+		return n, err
+	}
+	return n, errMeter
+}
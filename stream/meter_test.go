@@ -0,0 +1,130 @@
+package stream
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMeter(t *testing.T) {
+	input := strings.Repeat("cantabular,csv,row\n", 1000)
+	expectedMD5 := fmt.Sprintf("%x", md5.Sum([]byte(input)))
+	expectedSHA256 := fmt.Sprintf("%x", sha256.Sum256([]byte(input)))
+
+	Convey("Given a meter with checksums and a progress func that is called on every read", t, func() {
+		var reported []Progress
+		m := NewMeter(MeterConfig{
+			MD5:        true,
+			SHA256:     true,
+			OnProgress: func(p Progress) { reported = append(reported, p) },
+		})
+
+		Convey("When a reader wrapped by the meter is read in chunks", func() {
+			b, err := io.ReadAll(io.LimitReader(m.Reader(strings.NewReader(input)), int64(len(input)+1)))
+			So(err, ShouldBeNil)
+			So(string(b), ShouldEqual, input)
+
+			Convey("Then the meter accounts for all the bytes and rows read", func() {
+				p := m.Progress()
+				So(p.Bytes, ShouldEqual, len(input))
+				So(p.Rows, ShouldEqual, 1000)
+				So(m.MD5(), ShouldEqual, expectedMD5)
+				So(m.SHA256(), ShouldEqual, expectedSHA256)
+			})
+
+			Convey("Then the progress is reported as it is read, ending with the final progress", func() {
+				So(len(reported), ShouldBeGreaterThan, 1)
+				So(reported[len(reported)-1].Bytes, ShouldEqual, len(input))
+				So(reported[len(reported)-1].Rows, ShouldEqual, 1000)
+			})
+		})
+
+		Convey("When the meter is used as a consumer of Stream", func() {
+			out := &bytes.Buffer{}
+			err := Stream(ctx, io.NopCloser(strings.NewReader(input)), chunkedTransformer(100), m.Consumer(collectingConsumer(out)))
+
+			Convey("Then the meter accounts for the whole transformed stream", func() {
+				So(err, ShouldBeNil)
+				So(out.String(), ShouldEqual, input)
+				So(m.Progress().Rows, ShouldEqual, 1000)
+				So(m.SHA256(), ShouldEqual, expectedSHA256)
+				So(reported[len(reported)-1].Bytes, ShouldEqual, len(input))
+			})
+		})
+
+		Convey("When the meter is used as a stage of a pipeline", func() {
+			out := &bytes.Buffer{}
+			err := Pipeline(chunkedTransformer(100), m.Transformer())(ctx, strings.NewReader(input), out)
+
+			Convey("Then the meter accounts for the output of the previous stage", func() {
+				So(err, ShouldBeNil)
+				So(out.String(), ShouldEqual, input)
+				So(m.MD5(), ShouldEqual, expectedMD5)
+				So(reported[len(reported)-1].Rows, ShouldEqual, 1000)
+			})
+		})
+	})
+
+	Convey("Given a meter with a long progress interval", t, func() {
+		calls := 0
+		m := NewMeter(MeterConfig{Interval: time.Hour, OnProgress: func(p Progress) { calls++ }})
+
+		Convey("When a writer wrapped by the meter is written in chunks", func() {
+			w := m.Writer(io.Discard)
+			for i := 0; i < 10; i++ {
+				_, err := w.Write([]byte("a,b\n"))
+				So(err, ShouldBeNil)
+			}
+
+			Convey("Then the progress is only reported once", func() {
+				So(calls, ShouldEqual, 1)
+				So(m.Progress().Rows, ShouldEqual, 10)
+			})
+		})
+	})
+
+	Convey("Given a meter without checksums, then they are empty", t, func() {
+		m := NewMeter(MeterConfig{})
+		So(m.MD5(), ShouldBeEmpty)
+		So(m.SHA256(), ShouldBeEmpty)
+	})
+
+	Convey("Given a meter with a maximum size", t, func() {
+		m := NewMeter(MeterConfig{MaxSize: 10})
+
+		Convey("When more data is read through it", func() {
+			b, err := io.ReadAll(m.Reader(strings.NewReader(input)))
+
+			Convey("Then only the allowed data is read and a max size error is returned", func() {
+				var errMaxSize *MaxSizeError
+				So(errors.As(err, &errMaxSize), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "stream exceeded maximum size of 10 bytes")
+				So(string(b), ShouldEqual, input[:10])
+				So(m.Progress().Bytes, ShouldEqual, 10)
+			})
+		})
+
+		Convey("When more data is written through it by a stream transformer", func() {
+			out := &bytes.Buffer{}
+			err := Stream(ctx, io.NopCloser(strings.NewReader(input)), func(ctx context.Context, r io.Reader, w io.Writer) error {
+				_, err := io.Copy(m.Writer(w), r)
+				return err
+			}, collectingConsumer(out))
+
+			Convey("Then the stream fails with a max size error after writing the allowed data", func() {
+				So(err, ShouldNotBeNil)
+				So(err.Error(), ShouldStartWith, "transform error: stream exceeded maximum size of 10 bytes")
+				So(out.String(), ShouldEqual, input[:10])
+			})
+		})
+	})
+}
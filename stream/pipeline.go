@@ -0,0 +1,149 @@
+package stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Pipeline chains the provided transformers into a single Transformer, e.g. GraphQL JSON -> CSV -> gzip.
+// Each stage runs in its own go-routine, reading the output of the previous stage through a pipe.
+// If any stage fails, the pipes around it are closed with its error, so that the previous stage fails to write
+// and the next stage fails to read, and the context provided to all stages is cancelled.
+// The error of the first stage that failed is returned, along with its index in the pipeline.
+// If no stages are provided, the input is copied to the output unchanged.
+func Pipeline(stages ...Transformer) Transformer {
+	return func(ctx context.Context, r io.Reader, w io.Writer) error {
+		switch len(stages) {
+		case 0:
+			_, err := io.Copy(w, r)
+			return err
+		case 1:
+			return stages[0](ctx, r, w)
+		}
+
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		var errPipeline error
+		once := &sync.Once{}
+		wg := &sync.WaitGroup{}
+
+		var in io.Reader = r
+		for i, stage := range stages {
+			var out io.Writer = w
+			var pipeReader *io.PipeReader
+			var pipeWriter *io.PipeWriter
+			if i < len(stages)-1 {
+				pipeReader, pipeWriter = io.Pipe()
+				out = pipeWriter
+			}
+
+			// the pipe reader this stage reads from, if it is not the first one
+			inPipe, _ := in.(*io.PipeReader)
+			if i == 0 {
+				inPipe = nil
+			}
+
+			// Start go-routine to run the stage. When it finishes, its output pipe is closed (with error if the stage failed),
+			// and so is its input pipe, so that the previous stage is not blocked if this stage did not read all of its input.
+			wg.Add(1)
+			go func(i int, stage Transformer, in io.Reader, out io.Writer) {
+				defer wg.Done()
+				err := stage(ctx, in, out)
+				if err != nil {
+					once.Do(func() {
+						errPipeline = fmt.Errorf("pipeline stage %d error: %w", i, err)
+					})
+					cancel()
+				}
+				if pipeWriter != nil {
+					if errClose := pipeWriter.CloseWithError(err); errClose != nil {
+						log.Error(ctx, "stream error: error closing pipe writer of pipeline stage", errClose, log.Data{"stage": i})
+					}
+				}
+				if inPipe != nil {
+					if errClose := inPipe.CloseWithError(err); errClose != nil {
+						log.Error(ctx, "stream error: error closing pipe reader of pipeline stage", errClose, log.Data{"stage": i})
+					}
+				}
+			}(i, stage, in, out)
+
+			if pipeReader != nil {
+				in = pipeReader
+			}
+		}
+
+		wg.Wait()
+		return errPipeline
+	}
+}
+
+// Gzip returns a Transformer that compresses its input with gzip, using the default compression level
+func Gzip() Transformer {
+	return func(ctx context.Context, r io.Reader, w io.Writer) error {
+		gw := gzip.NewWriter(w)
+		if _, err := io.Copy(gw, r); err != nil {
+			return fmt.Errorf("failed to compress stream: %w", err)
+		}
+		if err := gw.Close(); err != nil {
+			return fmt.Errorf("failed to close gzip writer: %w", err)
+		}
+		return nil
+	}
+}
+
+// CountBytes returns a Transformer that copies its input unchanged, adding the number of bytes copied to n.
+// The counter is updated atomically as the data is copied, so it can be read while the stream is in progress.
+func CountBytes(n *int64) Transformer {
+	return func(ctx context.Context, r io.Reader, w io.Writer) error {
+		_, err := io.Copy(&countingWriter{w: w, n: n}, r)
+		return err
+	}
+}
+
+// CountLines returns a Transformer that copies its input unchanged, adding the number of lines copied to n.
+// A final line without a trailing newline is counted too. The counter is updated atomically as the data is copied.
+func CountLines(n *int64) Transformer {
+	return func(ctx context.Context, r io.Reader, w io.Writer) error {
+		buf := make([]byte, 32*1024)
+		last := byte('\n')
+		for {
+			nr, errRead := r.Read(buf)
+			if nr > 0 {
+				atomic.AddInt64(n, int64(bytes.Count(buf[:nr], []byte{'\n'})))
+				last = buf[nr-1]
+				if _, err := w.Write(buf[:nr]); err != nil {
+					return err
+				}
+			}
+			if errRead == io.EOF {
+				if last != '\n' {
+					atomic.AddInt64(n, 1)
+				}
+				return nil
+			}
+			if errRead != nil {
+				return errRead
+			}
+		}
+	}
+}
+
+// countingWriter is a writer that atomically adds the number of bytes written to the underlying writer to n
+type countingWriter struct {
+	w io.Writer
+	n *int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	written, err := cw.w.Write(p)
+	atomic.AddInt64(cw.n, int64(written))
+	return written, err
+}
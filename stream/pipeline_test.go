@@ -0,0 +1,125 @@
+package stream
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// upperTransformer writes its input in upper case
+func upperTransformer(ctx context.Context, r io.Reader, w io.Writer) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(bytes.ToUpper(b))
+	return err
+}
+
+func TestPipeline(t *testing.T) {
+	input := strings.Repeat("cantabular,csv,row\n", 1000) + "last,row"
+
+	Convey("Given a pipeline of transformers that count lines and bytes, change the case and compress the stream", t, func() {
+		var lines, rawBytes, compressedBytes int64
+		transform := Pipeline(CountLines(&lines), CountBytes(&rawBytes), upperTransformer, Gzip(), CountBytes(&compressedBytes))
+
+		Convey("When the stream is transformed with the pipeline", func() {
+			out := &bytes.Buffer{}
+			err := transform(ctx, strings.NewReader(input), out)
+			So(err, ShouldBeNil)
+
+			Convey("Then the output is the result of applying all the stages in order", func() {
+				gr, err := gzip.NewReader(out)
+				So(err, ShouldBeNil)
+				b, err := io.ReadAll(gr)
+				So(err, ShouldBeNil)
+				So(string(b), ShouldEqual, strings.ToUpper(input))
+			})
+
+			Convey("Then the counters are updated with the lines and bytes that went through their stages", func() {
+				So(lines, ShouldEqual, 1001)
+				So(rawBytes, ShouldEqual, len(input))
+				So(compressedBytes, ShouldEqual, out.Len())
+			})
+		})
+
+		Convey("When the pipeline is used by Stream", func() {
+			var compressed []byte
+			err := Stream(ctx, io.NopCloser(strings.NewReader(input)), transform, func(ctx context.Context, r io.Reader) error {
+				var err error
+				compressed, err = io.ReadAll(r)
+				return err
+			})
+
+			Convey("Then the consumer receives the output of the last stage", func() {
+				So(err, ShouldBeNil)
+				So(compressedBytes, ShouldEqual, len(compressed))
+			})
+		})
+	})
+
+	Convey("Given a pipeline with a failing stage in the middle", t, func() {
+		var lines int64
+		failing := func(ctx context.Context, r io.Reader, w io.Writer) error {
+			if _, err := r.Read(make([]byte, 10)); err != nil {
+				return err
+			}
+			return errTestTransformer
+		}
+		transform := Pipeline(CountLines(&lines), failing, upperTransformer)
+
+		Convey("When the stream is transformed with the pipeline", func() {
+			out := &bytes.Buffer{}
+			err := transform(ctx, strings.NewReader(input), out)
+
+			Convey("Then the error of the failing stage is returned and no output is written", func() {
+				So(err, ShouldNotBeNil)
+				So(errors.Is(err, errTestTransformer), ShouldBeTrue)
+				So(err.Error(), ShouldEqual, "pipeline stage 1 error: transfomer error")
+				So(out.Len(), ShouldEqual, 0)
+			})
+		})
+	})
+
+	Convey("Given a pipeline with a stage that stops reading its input successfully", t, func() {
+		head := func(ctx context.Context, r io.Reader, w io.Writer) error {
+			_, err := io.CopyN(w, r, 10)
+			return err
+		}
+		transform := Pipeline(upperTransformer, head)
+
+		Convey("Then the previous stage is not blocked and fails to write the rest of its output", func() {
+			err := transform(ctx, strings.NewReader(input), &bytes.Buffer{})
+			So(errors.Is(err, io.ErrClosedPipe), ShouldBeTrue)
+		})
+	})
+
+	Convey("Given an empty pipeline, then the input is copied unchanged", t, func() {
+		out := &bytes.Buffer{}
+		So(Pipeline()(ctx, strings.NewReader(input), out), ShouldBeNil)
+		So(out.String(), ShouldEqual, input)
+	})
+}
+
+func TestCountLines(t *testing.T) {
+	Convey("Given inputs with and without a trailing newline, then all the lines are counted", t, func() {
+		for input, expected := range map[string]int64{
+			"":           0,
+			"\n":         1,
+			"a,b\nc,d\n": 2,
+			"a,b\nc,d":   2,
+		} {
+			var lines int64
+			out := &bytes.Buffer{}
+			So(CountLines(&lines)(ctx, strings.NewReader(input), out), ShouldBeNil)
+			So(lines, ShouldEqual, expected)
+			So(out.String(), ShouldEqual, input)
+		}
+	})
+}
@@ -13,6 +13,7 @@ import (
 
 	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
 	dprequest "github.com/ONSdigital/dp-net/v2/request"
@@ -99,7 +100,7 @@ func (c *Client) Upload(ctx context.Context, fileContent io.ReadCloser, metadata
 				http.StatusBadRequest,
 				http.StatusUnauthorized,
 				http.StatusNotFound:
-				return dperrors.FromBody(resp.Body)
+				return dperrors.FromResponse(resp)
 			case http.StatusForbidden:
 				return ErrNotAuthorized
 			default:
@@ -111,6 +112,12 @@ func (c *Client) Upload(ctx context.Context, fileContent io.ReadCloser, metadata
 	return nil
 }
 
+// UploadWithMeter uploads the file content read through the provided meter,
+// e.g. to report the progress of a large upload, calculate its checksum or limit its size
+func (c *Client) UploadWithMeter(ctx context.Context, fileContent io.ReadCloser, metadata Metadata, m *stream.Meter) error {
+	return c.Upload(ctx, m.ReadCloser(fileContent), metadata)
+}
+
 func unsuccessfulRequest(statusCode int) bool {
 	return statusCode != http.StatusOK && statusCode != http.StatusCreated
 }
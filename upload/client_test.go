@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
+	"github.com/ONSdigital/dp-api-clients-go/v2/stream"
 	"github.com/ONSdigital/dp-api-clients-go/v2/upload"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-net/v2/request"
@@ -247,6 +248,32 @@ func TestUpload(t *testing.T) {
 					So(actualLicenceURL, ShouldEqual, licenseURL)
 				})
 			})
+			Convey("When I upload the single-chunk file with a meter", func() {
+				numberOfAPICalls = 0
+				var reported []stream.Progress
+				m := stream.NewMeter(stream.MeterConfig{MD5: true, OnProgress: func(p stream.Progress) { reported = append(reported, p) }})
+				err := c.UploadWithMeter(context.Background(), f, createMetadata(int64(len(fileContent)), nil), m)
+
+				Convey("Then the file is uploaded through the meter", func() {
+					So(err, ShouldBeNil)
+					So(actualContent, ShouldEqual, fileContent)
+					So(m.Progress().Bytes, ShouldEqual, len(fileContent))
+					So(m.MD5(), ShouldEqual, fmt.Sprintf("%x", md5.Sum([]byte(fileContent))))
+					So(reported, ShouldNotBeEmpty)
+					So(reported[len(reported)-1].Bytes, ShouldEqual, len(fileContent))
+				})
+			})
+
+			Convey("When I upload the single-chunk file with a meter that limits its size", func() {
+				m := stream.NewMeter(stream.MeterConfig{MaxSize: 4})
+				err := c.UploadWithMeter(context.Background(), f, createMetadata(int64(len(fileContent)), nil), m)
+
+				Convey("Then the upload fails with a max size error", func() {
+					var errMaxSize *stream.MaxSizeError
+					So(errors.As(err, &errMaxSize), ShouldBeTrue)
+					So(errMaxSize.MaxSize, ShouldEqual, 4)
+				})
+			})
 		})
 
 		Convey("And the file is multiple chunks", func() {
@@ -18,6 +18,8 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/clientlog"
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	healthcheck "github.com/ONSdigital/dp-api-clients-go/v2/health"
 	health "github.com/ONSdigital/dp-healthcheck/healthcheck"
 	dphttp "github.com/ONSdigital/dp-net/v2/http"
@@ -38,6 +40,7 @@ type Client struct {
 type ErrInvalidZebedeeResponse struct {
 	ActualCode int
 	URI        string
+	response   dperrors.ResponseContext
 }
 
 // Error should be called by the user to print out the stringified version of the error
@@ -48,6 +51,19 @@ func (e ErrInvalidZebedeeResponse) Error() string {
 	)
 }
 
+// Code returns the status code received from zebedee if an error is returned
+func (e ErrInvalidZebedeeResponse) Code() int {
+	return e.ActualCode
+}
+
+// LogData returns the details of the response received from zebedee
+func (e ErrInvalidZebedeeResponse) LogData() map[string]interface{} {
+	logData := e.response.LogData()
+	logData["status_code"] = e.ActualCode
+	logData["uri"] = e.URI
+	return logData
+}
+
 var _ error = ErrInvalidZebedeeResponse{}
 
 // New creates a new Zebedee Client, set ZEBEDEE_REQUEST_TIMEOUT_SECOND
@@ -79,8 +95,11 @@ func NewClientWithClienter(zebedeeURL string, clienter dphttp.Clienter) *Client
 // NewWithHealthClient creates a new instance of Client,
 // reusing the URL and Clienter from the provided health check client.
 func NewWithHealthClient(hcCli *healthcheck.Client) *Client {
+	hcClient := healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client)
+	hcClient.LatencyThresholds = hcCli.LatencyThresholds
+
 	return &Client{
-		healthcheck.NewClientWithClienter(service, hcCli.URL, hcCli.Client),
+		hcClient,
 	}
 }
 
@@ -161,15 +180,16 @@ func (c *Client) get(ctx context.Context, userAccessToken, path string) ([]byte,
 
 	dprequest.AddFlorenceHeader(req, userAccessToken)
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := clientlog.DoRequest(ctx, c.hcCli.Client, req, "retrieving content", service)
 	if err != nil {
 		return nil, nil, err
 	}
 	defer closeResponseBody(ctx, resp)
 
 	if resp.StatusCode < 200 || resp.StatusCode > 399 {
+		err = ErrInvalidZebedeeResponse{resp.StatusCode, req.URL.Path, dperrors.NewResponseContext(resp)}
 		io.Copy(ioutil.Discard, resp.Body)
-		return nil, nil, ErrInvalidZebedeeResponse{resp.StatusCode, req.URL.Path}
+		return nil, nil, err
 	}
 
 	b, err := ioutil.ReadAll(resp.Body)
@@ -184,7 +204,7 @@ func (c *Client) put(ctx context.Context, userAccessToken, path string, payload
 
 	dprequest.AddFlorenceHeader(req, userAccessToken)
 
-	resp, err := c.hcCli.Client.Do(ctx, req)
+	resp, err := clientlog.DoRequest(ctx, c.hcCli.Client, req, "updating content", service)
 	if err != nil {
 		return nil, err
 	}
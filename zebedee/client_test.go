@@ -12,6 +12,7 @@ import (
 	"testing"
 	"time"
 
+	dperrors "github.com/ONSdigital/dp-api-clients-go/v2/errors"
 	"github.com/ONSdigital/dp-api-clients-go/v2/health"
 	"github.com/ONSdigital/dp-healthcheck/healthcheck"
 	"github.com/ONSdigital/dp-mocking/httpmocks"
@@ -1124,6 +1125,20 @@ func TestClient_PublishedDataEndpoint(t *testing.T) {
 			})
 		})
 	})
+	Convey("given a 500 response with a body", t, func() {
+		response := httpmocks.NewResponseMock(httpmocks.NewReadCloserMock([]byte("content not found"), nil), http.StatusInternalServerError)
+		httpClient := newMockHTTPClient(response, nil)
+		zebedeeClient := newZebedeeClient(httpClient)
+
+		Convey("when zebedeeClient.GetPublishedData is called", func() {
+			_, err := zebedeeClient.GetPublishedData(ctx, testURIString)
+
+			Convey("then the expected error is returned, with the response body in its log data", func() {
+				So(err, ShouldHaveSameTypeAs, ErrInvalidZebedeeResponse{})
+				So(dperrors.LogData(err)["response_body"], ShouldEqual, "content not found")
+			})
+		})
+	})
 }
 
 func TestClient_PublishedIndexEndpoint(t *testing.T) {